@@ -0,0 +1,234 @@
+package q
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+)
+
+// binaryVersion is the version of the binary encoding written by MarshalBinary.
+const binaryVersion = 1
+
+// Binary encoding kinds, which identify the collection that wrote an encoding.
+const (
+	binaryList byte = iota + 1
+	binarySet
+	binaryCounter
+	binaryHeap
+)
+
+// counterPayload is the binary payload of a Counter.
+type counterPayload[T comparable] struct {
+	Elements []T
+	Counts   []int
+}
+
+// marshalBinary encodes a version byte, a kind byte, the number of elements as a uvarint and a gob-encoded payload.
+// The payload carries gob's type descriptors, so the format favours self-description and safe decoding over size.
+// Time complexity: O(n), where n is the number of elements in the payload.
+func marshalBinary(kind byte, n int, payload any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write([]byte{binaryVersion, kind})
+	buf.Write(binary.AppendUvarint(nil, uint64(n)))
+	if err := gob.NewEncoder(&buf).Encode(payload); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalBinary decodes data written by marshalBinary into payload and returns the number of elements in the header.
+// Time complexity: O(n), where n is the length of data.
+func unmarshalBinary(data []byte, kind byte, payload any) (int, error) {
+	if len(data) < 2 {
+		return 0, fmt.Errorf("%w: too short", ErrInvalidEncoding)
+	}
+	if data[0] != binaryVersion {
+		return 0, fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, data[0])
+	}
+	if data[1] != kind {
+		return 0, fmt.Errorf("%w: unexpected kind %d", ErrInvalidEncoding, data[1])
+	}
+	n, size := binary.Uvarint(data[2:])
+	if size <= 0 {
+		return 0, fmt.Errorf("%w: bad length prefix", ErrInvalidEncoding)
+	}
+	if err := gob.NewDecoder(bytes.NewReader(data[2+size:])).Decode(payload); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	return int(n), nil
+}
+
+// checkLength returns an error if the decoded number of elements does not match the header.
+// Time complexity: O(1).
+func checkLength(want, got int) error {
+	if want != got {
+		return fmt.Errorf("%w: header has %d elements but payload has %d", ErrInvalidEncoding, want, got)
+	}
+	return nil
+}
+
+// MarshalBinary encodes the list in a versioned binary format.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (l *List[M]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(binaryList, l.Len(), l.Elements())
+}
+
+// UnmarshalBinary replaces the contents of the list with data written by MarshalBinary.
+// Time complexity: O(n + m), where n is the number of elements in the list and m is the number of values decoded.
+func (l *List[M]) UnmarshalBinary(data []byte) error {
+	var values []M
+	n, err := unmarshalBinary(data, binaryList, &values)
+	if err != nil {
+		return err
+	}
+	if err := checkLength(n, len(values)); err != nil {
+		return err
+	}
+	l.Clear()
+	l.PushRight(values...)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using MarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (l *List[M]) GobEncode() ([]byte, error) {
+	return l.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using UnmarshalBinary.
+// Time complexity: O(n + m), where n is the number of elements in the list and m is the number of values decoded.
+func (l *List[M]) GobDecode(data []byte) error {
+	return l.UnmarshalBinary(data)
+}
+
+// MarshalBinary encodes the set in a versioned binary format.
+// Time complexity: O(n), where n is the number of elements in the set.
+func (s *Set[T]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(binarySet, s.Len(), s.Elements())
+}
+
+// UnmarshalBinary replaces the contents of the set with data written by MarshalBinary.
+// Time complexity: O(m), where m is the number of values decoded.
+func (s *Set[T]) UnmarshalBinary(data []byte) error {
+	var values []T
+	n, err := unmarshalBinary(data, binarySet, &values)
+	if err != nil {
+		return err
+	}
+	if err := checkLength(n, len(values)); err != nil {
+		return err
+	}
+	s.Clear()
+	s.Add(values...)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using MarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the set.
+func (s *Set[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using UnmarshalBinary.
+// Time complexity: O(m), where m is the number of values decoded.
+func (s *Set[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+// MarshalBinary encodes the counter in a versioned binary format.
+// Time complexity: O(n), where n is the number of unique elements in the counter.
+func (c *Counter[T]) MarshalBinary() ([]byte, error) {
+	payload := counterPayload[T]{
+		Elements: make([]T, 0, len(c.data)),
+		Counts:   make([]int, 0, len(c.data)),
+	}
+	for element, count := range c.data {
+		payload.Elements = append(payload.Elements, element)
+		payload.Counts = append(payload.Counts, count)
+	}
+	return marshalBinary(binaryCounter, len(c.data), payload)
+}
+
+// UnmarshalBinary replaces the contents of the counter with data written by MarshalBinary.
+// Time complexity: O(m), where m is the number of elements decoded.
+func (c *Counter[T]) UnmarshalBinary(data []byte) error {
+	var payload counterPayload[T]
+	n, err := unmarshalBinary(data, binaryCounter, &payload)
+	if err != nil {
+		return err
+	}
+	if err := checkLength(n, len(payload.Elements)); err != nil {
+		return err
+	}
+	if err := checkLength(n, len(payload.Counts)); err != nil {
+		return err
+	}
+	for _, count := range payload.Counts {
+		if count < 1 {
+			return fmt.Errorf("%w: invalid count %d", ErrInvalidEncoding, count)
+		}
+	}
+	c.Clear()
+	c.addCounts(payload.Elements, payload.Counts)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using MarshalBinary.
+// Time complexity: O(n), where n is the number of unique elements in the counter.
+func (c *Counter[T]) GobEncode() ([]byte, error) {
+	return c.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using UnmarshalBinary.
+// Time complexity: O(m), where m is the number of elements decoded.
+func (c *Counter[T]) GobDecode(data []byte) error {
+	return c.UnmarshalBinary(data)
+}
+
+// MarshalBinary encodes the heap in a versioned binary format.
+// Time complexity: O(n), where n is the number of elements in the heap.
+func (h *Heap[M]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(binaryHeap, h.Len(), h.data)
+}
+
+// UnmarshalBinary replaces the contents of the heap with data written by MarshalBinary and restores the heap property.
+// The heap must have been created with NewHeap; use UnmarshalHeapBinary to decode into a new heap instead.
+// Time complexity: O(m), where m is the number of values decoded.
+func (h *Heap[M]) UnmarshalBinary(data []byte) error {
+	if err := h.requireLess(); err != nil {
+		return err
+	}
+	var values []M
+	n, err := unmarshalBinary(data, binaryHeap, &values)
+	if err != nil {
+		return err
+	}
+	if err := checkLength(n, len(values)); err != nil {
+		return err
+	}
+	h.reset(values)
+	return nil
+}
+
+// UnmarshalHeapBinary decodes data written by MarshalBinary into a new Heap ordered by the less function.
+// Time complexity: O(n), where n is the number of values.
+func UnmarshalHeapBinary[M any](data []byte, less func(a, b M) bool) (*Heap[M], error) {
+	h := NewHeap(less)
+	if err := h.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// GobEncode implements gob.GobEncoder using MarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the heap.
+func (h *Heap[M]) GobEncode() ([]byte, error) {
+	return h.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using UnmarshalBinary.
+// Time complexity: O(m), where m is the number of values decoded.
+func (h *Heap[M]) GobDecode(data []byte) error {
+	return h.UnmarshalBinary(data)
+}
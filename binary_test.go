@@ -0,0 +1,80 @@
+package q
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListBinary(t *testing.T) {
+	assert := assert.New(t)
+	data, err := NewList(1, 2, 3).MarshalBinary()
+	assert.NoError(err)
+	assert.Equal(byte(binaryVersion), data[0])
+
+	l := NewList(9)
+	assert.NoError(l.UnmarshalBinary(data))
+	assert.Equal([]int{1, 2, 3}, l.Elements())
+
+	data, err = NewList[int]().MarshalBinary()
+	assert.NoError(err)
+	assert.NoError(l.UnmarshalBinary(data))
+	assert.Equal(0, l.Len())
+}
+
+func TestBinaryInvalid(t *testing.T) {
+	assert := assert.New(t)
+	data, err := NewList(1, 2, 3).MarshalBinary()
+	assert.NoError(err)
+
+	assert.ErrorIs(NewSet[int]().UnmarshalBinary(data), ErrInvalidEncoding)
+	assert.ErrorIs(NewList[int]().UnmarshalBinary(nil), ErrInvalidEncoding)
+	assert.ErrorIs(NewList[string]().UnmarshalBinary(data), ErrInvalidEncoding)
+
+	wrongVersion := bytes.Clone(data)
+	wrongVersion[0] = binaryVersion + 1
+	assert.ErrorIs(NewList[int]().UnmarshalBinary(wrongVersion), ErrInvalidEncoding)
+
+	wrongLength := bytes.Clone(data)
+	wrongLength[2] = 4
+	assert.ErrorIs(NewList[int]().UnmarshalBinary(wrongLength), ErrInvalidEncoding)
+}
+
+func TestCollectionsGob(t *testing.T) {
+	assert := assert.New(t)
+	type snapshot struct {
+		List    *List[string]
+		Set     *Set[int]
+		Counter *Counter[string]
+		Heap    *Heap[int]
+	}
+	in := snapshot{
+		List:    NewList("a", "b"),
+		Set:     NewSet(1, 2, 3),
+		Counter: NewCounter("x", "y", "x"),
+		Heap:    NewMinHeap(5, 3, 4),
+	}
+	var buf bytes.Buffer
+	assert.NoError(gob.NewEncoder(&buf).Encode(in))
+
+	out := snapshot{Heap: NewMinHeap[int]()}
+	assert.NoError(gob.NewDecoder(&buf).Decode(&out))
+	assert.Equal([]string{"a", "b"}, out.List.Elements())
+	assert.ElementsMatch([]int{1, 2, 3}, out.Set.Elements())
+	assert.True(in.Counter.Equal(out.Counter))
+	assert.Equal(3, out.Heap.Pop())
+	assert.Equal(4, out.Heap.Pop())
+	assert.Equal(5, out.Heap.Pop())
+}
+
+func TestUnmarshalHeapBinary(t *testing.T) {
+	assert := assert.New(t)
+	data, err := NewMaxHeap(1, 5, 3).MarshalBinary()
+	assert.NoError(err)
+	h, err := UnmarshalHeapBinary(data, Less[int])
+	assert.NoError(err)
+	assert.Equal(1, h.Pop())
+	assert.Error((&Heap[int]{}).UnmarshalBinary(data))
+}
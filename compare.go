@@ -0,0 +1,54 @@
+package q
+
+import "cmp"
+
+// Less reports whether a is less than b. It can be passed anywhere a less function is expected.
+// NaN values are ordered before all other values, as in cmp.Less.
+// Time complexity: O(1).
+func Less[T cmp.Ordered](a, b T) bool {
+	return cmp.Less(a, b)
+}
+
+// Greater reports whether a is greater than b. Passing it as a less function orders values from largest to smallest.
+// Time complexity: O(1).
+func Greater[T cmp.Ordered](a, b T) bool {
+	return cmp.Less(b, a)
+}
+
+// By returns a less function that orders values by the key returned by the callback function.
+// Time complexity: O(1), plus the cost of the key function on each comparison.
+func By[M any, K cmp.Ordered](key func(M) K) func(a, b M) bool {
+	return func(a, b M) bool {
+		return cmp.Less(key(a), key(b))
+	}
+}
+
+// Reverse returns a less function that orders values in the opposite order of less.
+// Time complexity: O(1), plus the cost of less on each comparison.
+func Reverse[M any](less func(a, b M) bool) func(a, b M) bool {
+	return func(a, b M) bool {
+		return less(b, a)
+	}
+}
+
+// ThenBy returns a less function that orders values by first and breaks ties with second.
+// Time complexity: O(1), plus the cost of first and second on each comparison.
+func ThenBy[M any](first, second func(a, b M) bool) func(a, b M) bool {
+	return func(a, b M) bool {
+		if first(a, b) {
+			return true
+		}
+		if first(b, a) {
+			return false
+		}
+		return second(a, b)
+	}
+}
+
+// FromCompare returns a less function from a three-way comparison function such as cmp.Compare or strings.Compare.
+// Time complexity: O(1), plus the cost of compare on each comparison.
+func FromCompare[M any](compare func(a, b M) int) func(a, b M) bool {
+	return func(a, b M) bool {
+		return compare(a, b) < 0
+	}
+}
@@ -0,0 +1,56 @@
+package q
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComparators(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(Less(1, 2))
+	assert.False(Less(2, 2))
+	assert.True(Greater("b", "a"))
+	assert.False(Greater("a", "a"))
+	assert.True(Reverse(Less[int])(2, 1))
+	assert.True(FromCompare(strings.Compare)("a", "b"))
+	assert.False(FromCompare(cmp.Compare[int])(1, 1))
+}
+
+func TestByThenBy(t *testing.T) {
+	assert := assert.New(t)
+	type person struct {
+		name string
+		age  int
+	}
+	people := NewList(
+		person{"carol", 30},
+		person{"alice", 30},
+		person{"bob", 25},
+	)
+	byAge := By(func(p person) int { return p.age })
+	byName := By(func(p person) string { return p.name })
+	sorted := people.Sort(ThenBy(Reverse(byAge), byName))
+	names := Map(sorted, func(p person) string { return p.name })
+	assert.Equal([]string{"alice", "carol", "bob"}, names.Elements())
+}
+
+func TestOrderedConstructors(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(1, NewMinHeap(3, 1, 2).Top())
+	assert.Equal(3, NewMaxHeap(3, 1, 2).Top())
+
+	l := NewList("b", "c", "a")
+	assert.Equal([]string{"a", "b", "c"}, SortOrdered(l).Elements())
+	assert.Equal([]string{"b", "c", "a"}, l.Elements())
+}
+
+// ExampleNewMaxHeap demonstrates how to build a max-heap of ordered values.
+func ExampleNewMaxHeap() {
+	h := NewMaxHeap(5, 2, 7, 1, 9)
+	fmt.Println(h.Pop(), h.Pop(), h.Pop())
+	// Output: 9 7 5
+}
@@ -1,11 +1,17 @@
 package q
 
-import "fmt"
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
 
 // Counter is a generic counter data structure that stores unique elements of type T and counts occurences.
 type Counter[T comparable] struct {
-	size int
-	data map[T]int
+	size      int
+	data      map[T]int
+	tx        *txLog
+	observers observerList[T]
 }
 
 // NewCounter creates a new Counter and returns a pointer to it.
@@ -19,9 +25,17 @@ func NewCounter[T comparable](elements ...T) *Counter[T] {
 // Add adds one or more elements to the counter.
 // Time complexity: O(n), where n is the number of elements being added.
 func (c *Counter[T]) Add(elements ...T) {
+	if c.tx != nil && len(elements) > 0 {
+		added := slices.Clone(elements)
+		c.tx.record(func() { c.Remove(added...) })
+	}
 	c.size += len(elements)
 	for _, element := range elements {
 		c.data[element]++
+		if len(c.observers) > 0 {
+			count := c.data[element]
+			c.observers.emit(Event[T]{Kind: EventCountChanged, Value: element, Index: -1, OldCount: count - 1, NewCount: count})
+		}
 	}
 }
 
@@ -29,20 +43,64 @@ func (c *Counter[T]) Add(elements ...T) {
 // Time complexity: O(1).
 func (c *Counter[T]) Remove(elements ...T) bool {
 	allRemoved := true
+	var removed []T
 	for _, element := range elements {
 		if c.Contains(element) {
+			if c.tx != nil {
+				removed = append(removed, element)
+			}
 			c.size--
 			c.data[element]--
-			if c.data[element] == 0 {
+			count := c.data[element]
+			if count == 0 {
 				delete(c.data, element)
 			}
+			if len(c.observers) > 0 {
+				c.observers.emit(Event[T]{Kind: EventCountChanged, Value: element, Index: -1, OldCount: count + 1, NewCount: count})
+			}
 		} else {
 			allRemoved = false
 		}
 	}
+	if len(removed) > 0 {
+		c.tx.record(func() { c.Add(removed...) })
+	}
 	return allRemoved
 }
 
+// addCounts adds each element with the count at the same index, reporting one EventCountChanged per element.
+// The decoders use it to load counts without adding elements one occurrence at a time.
+// Time complexity: O(n), where n is the number of elements.
+func (c *Counter[T]) addCounts(elements []T, counts []int) {
+	if c.tx != nil && len(elements) > 0 {
+		elements, counts := slices.Clone(elements), slices.Clone(counts)
+		c.tx.record(func() {
+			for i := len(elements) - 1; i >= 0; i-- {
+				c.addCount(elements[i], -counts[i])
+			}
+		})
+	}
+	for i, element := range elements {
+		c.addCount(element, counts[i])
+	}
+}
+
+// addCount changes the count of an element by n, removing it once its count reaches zero.
+// Time complexity: O(1).
+func (c *Counter[T]) addCount(element T, n int) {
+	old := c.data[element]
+	count := old + n
+	if count > 0 {
+		c.data[element] = count
+	} else {
+		delete(c.data, element)
+	}
+	c.size += n
+	if len(c.observers) > 0 {
+		c.observers.emit(Event[T]{Kind: EventCountChanged, Value: element, Index: -1, OldCount: old, NewCount: count})
+	}
+}
+
 // Contains checks if an element is present in the counter.
 // Time complexity: O(1).
 func (c *Counter[T]) Contains(element T) bool {
@@ -68,8 +126,18 @@ func (c *Counter[T]) Len() int {
 // Clear removes all elements from the counter.
 // Time complexity: O(1).
 func (c *Counter[T]) Clear() {
+	if c.tx != nil {
+		size, data := c.size, c.data
+		c.tx.record(func() {
+			c.size, c.data = size, data
+			for element, count := range data {
+				c.observers.emit(Event[T]{Kind: EventCountChanged, Value: element, Index: -1, NewCount: count})
+			}
+		})
+	}
 	c.size = 0
 	c.data = make(map[T]int)
+	c.observers.emit(Event[T]{Kind: EventCleared, Index: -1})
 }
 
 // Elements returns a slice containing all the elements in the counter.
@@ -82,6 +150,44 @@ func (c *Counter[T]) Elements() []T {
 	return elements
 }
 
+// All returns an iterator over the unique elements in the counter and their counts, in no particular order.
+// Time complexity: O(n), where n is the number of unique elements in the counter.
+func (c *Counter[T]) All() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for element, count := range c.data {
+			if !yield(element, count) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the unique elements in the counter, in no particular order.
+// Time complexity: O(n), where n is the number of unique elements in the counter.
+func (c *Counter[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for element := range c.data {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Stream returns a lazy stream over the elements in the counter, repeating each element as many times as it was counted.
+// Time complexity: O(1).
+func (c *Counter[T]) Stream() *Stream[T] {
+	return NewStream(func(yield func(T) bool) {
+		for element, count := range c.data {
+			for i := 0; i < count; i++ {
+				if !yield(element) {
+					return
+				}
+			}
+		}
+	})
+}
+
 // Equal checks if the counter is equal to another counter.
 // Time complexity: O(n), where n is the number of elements in the counter.
 func (c *Counter[T]) Equal(other *Counter[T]) bool {
@@ -96,10 +202,10 @@ func (c *Counter[T]) Equal(other *Counter[T]) bool {
 	return true
 }
 
-// String returns a string representation of the counter.
-// Time complexity: O(n), where n is the number of elements in the counter.
+// String returns a string representation of the counter, sorted if its elements are of an ordered type.
+// Time complexity: O(n log n), where n is the number of elements in the counter.
 func (c *Counter[T]) String() string {
-	return fmt.Sprintf("%v", c.data)
+	return fmt.Sprintf("%v", c)
 }
 
 // IsEmpty checks if the counter is empty.
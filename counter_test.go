@@ -1,6 +1,8 @@
 package q
 
 import (
+	"maps"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -73,3 +75,10 @@ func TestCounterRemove(t *testing.T) {
 	assert.Equal(3, counter.Len())
 	assert.False(counter.Remove("z"))
 }
+
+func TestCounterAll(t *testing.T) {
+	assert := assert.New(t)
+	counter := NewCounter("a", "b", "a")
+	assert.Equal(map[string]int{"a": 2, "b": 1}, maps.Collect(counter.All()))
+	assert.ElementsMatch([]string{"a", "b"}, slices.Collect(counter.Values()))
+}
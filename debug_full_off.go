@@ -0,0 +1,7 @@
+//go:build !qdebug_full
+
+package q
+
+// debugFull runs Validate after every mutation of a List or Heap, checking the links of every node and the heap
+// property at every element. It is set by building with -tags qdebug_full and makes each mutation O(n).
+const debugFull = false
@@ -0,0 +1,20 @@
+//go:build qdebug_full
+
+package q
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugFullChecks(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2, 3, 4, 5)
+	l.head.next.next.prev = l.head
+	assert.Panics(func() { l.PushRight(6) })
+
+	h := NewMinHeap(1, 2, 3, 4, 5, 6, 7, 8)
+	h.data[7] = 0
+	assert.Panics(func() { h.Push(9) })
+}
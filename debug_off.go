@@ -0,0 +1,8 @@
+//go:build !qdebug
+
+package q
+
+// debug enables constant-time invariant checks after every mutation of a List or Heap. It is set by building
+// with -tags qdebug. The checks are partial: they cover the ends of a list and the top and last elements of a
+// heap, so corruption in the middle is only caught by Validate or by building with -tags qdebug_full.
+const debug = false
@@ -0,0 +1,20 @@
+//go:build qdebug || qdebug_full
+
+package q
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugChecks(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2, 3)
+	l.head.list = nil
+	assert.Panics(func() { l.PushRight(4) })
+
+	h := NewMinHeap(1, 2, 3)
+	h.data[0] = 100
+	assert.Panics(func() { h.Push(50) })
+}
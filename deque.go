@@ -0,0 +1,348 @@
+package q
+
+import (
+	"fmt"
+	"iter"
+)
+
+// minDequeCapacity is the smallest buffer a non-empty Deque allocates. Capacities are always powers of two.
+const minDequeCapacity = 8
+
+// Deque is a generic double-ended queue backed by a growable circular buffer.
+// The buffer doubles when full and halves when it is at most a quarter full.
+type Deque[M any] struct {
+	data   []M
+	head   int
+	length int
+}
+
+// NewDeque creates a new Deque and initializes it with the given elements.
+// Time complexity: O(n), where n is the number of elements.
+func NewDeque[M any](elements ...M) *Deque[M] {
+	d := &Deque[M]{}
+	d.PushRight(elements...)
+	return d
+}
+
+// Push adds values to the deque.
+// Time complexity: amortized O(1) for each value.
+func (d *Deque[M]) Push(values ...M) {
+	d.PushRight(values...)
+}
+
+// Pop removes and returns the last value from the deque.
+// Time complexity: amortized O(1).
+func (d *Deque[M]) Pop() M {
+	return d.PopRight()
+}
+
+// TryPop removes and returns the last value from the deque. It returns false if the deque is empty.
+// Time complexity: amortized O(1).
+func (d *Deque[M]) TryPop() (M, bool) {
+	return d.TryPopRight()
+}
+
+// PopErr removes and returns the last value from the deque. It returns ErrEmpty if the deque is empty.
+// Time complexity: amortized O(1).
+func (d *Deque[M]) PopErr() (M, error) {
+	return d.PopRightErr()
+}
+
+// PushRight adds values to the end of the deque.
+// Time complexity: amortized O(1) for each value.
+func (d *Deque[M]) PushRight(values ...M) {
+	for _, v := range values {
+		d.grow()
+		d.data[d.index(d.length)] = v
+		d.length++
+	}
+}
+
+// PushLeft adds values to the beginning of the deque.
+// Time complexity: amortized O(1) for each value.
+func (d *Deque[M]) PushLeft(values ...M) {
+	for _, v := range values {
+		d.grow()
+		d.head = d.index(len(d.data) - 1)
+		d.data[d.head] = v
+		d.length++
+	}
+}
+
+// PopRight removes and returns the last value from the deque.
+// Time complexity: amortized O(1).
+func (d *Deque[M]) PopRight() M {
+	value, _ := d.TryPopRight()
+	return value
+}
+
+// TryPopRight removes and returns the last value from the deque. It returns false if the deque is empty.
+// Time complexity: amortized O(1).
+func (d *Deque[M]) TryPopRight() (M, bool) {
+	var m M
+	if d.length == 0 {
+		return m, false
+	}
+	i := d.index(d.length - 1)
+	value := d.data[i]
+	d.data[i] = m
+	d.length--
+	d.shrink()
+	return value, true
+}
+
+// PopRightErr removes and returns the last value from the deque. It returns ErrEmpty if the deque is empty.
+// Time complexity: amortized O(1).
+func (d *Deque[M]) PopRightErr() (M, error) {
+	value, ok := d.TryPopRight()
+	if !ok {
+		return value, ErrEmpty
+	}
+	return value, nil
+}
+
+// PopLeft removes and returns the first value from the deque.
+// Time complexity: amortized O(1).
+func (d *Deque[M]) PopLeft() M {
+	value, _ := d.TryPopLeft()
+	return value
+}
+
+// TryPopLeft removes and returns the first value from the deque. It returns false if the deque is empty.
+// Time complexity: amortized O(1).
+func (d *Deque[M]) TryPopLeft() (M, bool) {
+	var m M
+	if d.length == 0 {
+		return m, false
+	}
+	value := d.data[d.head]
+	d.data[d.head] = m
+	d.head = d.index(1)
+	d.length--
+	d.shrink()
+	return value, true
+}
+
+// PopLeftErr removes and returns the first value from the deque. It returns ErrEmpty if the deque is empty.
+// Time complexity: amortized O(1).
+func (d *Deque[M]) PopLeftErr() (M, error) {
+	value, ok := d.TryPopLeft()
+	if !ok {
+		return value, ErrEmpty
+	}
+	return value, nil
+}
+
+// PeekLeft returns the first value in the deque without removing it.
+// Time complexity: O(1).
+func (d *Deque[M]) PeekLeft() M {
+	value, _ := d.TryPeekLeft()
+	return value
+}
+
+// TryPeekLeft returns the first value in the deque without removing it. It returns false if the deque is empty.
+// Time complexity: O(1).
+func (d *Deque[M]) TryPeekLeft() (M, bool) {
+	if d.length == 0 {
+		var m M
+		return m, false
+	}
+	return d.data[d.head], true
+}
+
+// PeekLeftErr returns the first value in the deque without removing it. It returns ErrEmpty if the deque is empty.
+// Time complexity: O(1).
+func (d *Deque[M]) PeekLeftErr() (M, error) {
+	value, ok := d.TryPeekLeft()
+	if !ok {
+		return value, ErrEmpty
+	}
+	return value, nil
+}
+
+// PeekRight returns the last value in the deque without removing it.
+// Time complexity: O(1).
+func (d *Deque[M]) PeekRight() M {
+	value, _ := d.TryPeekRight()
+	return value
+}
+
+// TryPeekRight returns the last value in the deque without removing it. It returns false if the deque is empty.
+// Time complexity: O(1).
+func (d *Deque[M]) TryPeekRight() (M, bool) {
+	if d.length == 0 {
+		var m M
+		return m, false
+	}
+	return d.data[d.index(d.length-1)], true
+}
+
+// PeekRightErr returns the last value in the deque without removing it. It returns ErrEmpty if the deque is empty.
+// Time complexity: O(1).
+func (d *Deque[M]) PeekRightErr() (M, error) {
+	value, ok := d.TryPeekRight()
+	if !ok {
+		return value, ErrEmpty
+	}
+	return value, nil
+}
+
+// At returns the value at index i. Negative indices count back from the end of the deque.
+// It returns ErrOutOfRange if the index is outside the deque.
+// Time complexity: O(1).
+func (d *Deque[M]) At(i int) (M, error) {
+	j, err := d.position(i)
+	if err != nil {
+		var m M
+		return m, err
+	}
+	return d.data[j], nil
+}
+
+// SetAt replaces the value at index i. Negative indices count back from the end of the deque.
+// It returns ErrOutOfRange if the index is outside the deque.
+// Time complexity: O(1).
+func (d *Deque[M]) SetAt(i int, value M) error {
+	j, err := d.position(i)
+	if err != nil {
+		return err
+	}
+	d.data[j] = value
+	return nil
+}
+
+// Len returns the number of elements in the deque.
+// Time complexity: O(1).
+func (d *Deque[M]) Len() int {
+	return d.length
+}
+
+// Cap returns the number of elements the deque can hold before it grows.
+// Time complexity: O(1).
+func (d *Deque[M]) Cap() int {
+	return len(d.data)
+}
+
+// Clear removes all elements from the deque and releases its buffer.
+// Time complexity: O(1).
+func (d *Deque[M]) Clear() {
+	d.data = nil
+	d.head = 0
+	d.length = 0
+}
+
+// Each applies a callback function to each value in the deque.
+// Time complexity: O(n), where n is the number of elements in the deque.
+func (d *Deque[M]) Each(callback func(int, M)) {
+	for i := 0; i < d.length; i++ {
+		callback(i, d.data[d.index(i)])
+	}
+}
+
+// Iter returns an iterator over the index and value of each element in the deque, from left to right.
+// Time complexity: O(n), where n is the number of elements in the deque.
+func (d *Deque[M]) Iter() iter.Seq2[int, M] {
+	return func(yield func(int, M) bool) {
+		for i := 0; i < d.length; i++ {
+			if !yield(i, d.data[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the index and value of each element in the deque, from right to left.
+// Time complexity: O(n), where n is the number of elements in the deque.
+func (d *Deque[M]) Backward() iter.Seq2[int, M] {
+	return func(yield func(int, M) bool) {
+		for i := d.length - 1; i >= 0; i-- {
+			if !yield(i, d.data[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values in the deque, from left to right.
+// Time complexity: O(n), where n is the number of elements in the deque.
+func (d *Deque[M]) Values() iter.Seq[M] {
+	return func(yield func(M) bool) {
+		for i := 0; i < d.length; i++ {
+			if !yield(d.data[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Stream returns a lazy stream over the values in the deque, from left to right.
+// Time complexity: O(1).
+func (d *Deque[M]) Stream() *Stream[M] {
+	return NewStream(d.Values())
+}
+
+// Elements returns a slice containing all the values in the deque.
+// Time complexity: O(n), where n is the number of elements in the deque.
+func (d *Deque[M]) Elements() []M {
+	result := make([]M, d.length)
+	d.copyTo(result)
+	return result
+}
+
+// String returns a string representation of the deque.
+// Time complexity: O(n), where n is the number of elements in the deque.
+func (d *Deque[M]) String() string {
+	return fmt.Sprintf("%v", d.Elements())
+}
+
+// index maps an offset from the head of the deque to a position in the buffer.
+// Time complexity: O(1).
+func (d *Deque[M]) index(i int) int {
+	return (d.head + i) & (len(d.data) - 1)
+}
+
+// position resolves a possibly negative index to a position in the buffer.
+// Time complexity: O(1).
+func (d *Deque[M]) position(i int) (int, error) {
+	i, err := resolveIndex(i, d.length, false)
+	if err != nil {
+		return 0, err
+	}
+	return d.index(i), nil
+}
+
+// grow doubles the buffer if it has no room for another element.
+// Time complexity: amortized O(1).
+func (d *Deque[M]) grow() {
+	if d.length < len(d.data) {
+		return
+	}
+	d.resize(max(2*len(d.data), minDequeCapacity))
+}
+
+// shrink halves the buffer once it is at most a quarter full.
+// Time complexity: amortized O(1).
+func (d *Deque[M]) shrink() {
+	if len(d.data) > minDequeCapacity && d.length <= len(d.data)/4 {
+		d.resize(len(d.data) / 2)
+	}
+}
+
+// resize moves the elements into a new buffer of the given capacity, starting at position zero.
+// Time complexity: O(n), where n is the number of elements in the deque.
+func (d *Deque[M]) resize(capacity int) {
+	data := make([]M, capacity)
+	d.copyTo(data)
+	d.data = data
+	d.head = 0
+}
+
+// copyTo copies the elements of the deque, in order, into dst.
+// Time complexity: O(n), where n is the number of elements in the deque.
+func (d *Deque[M]) copyTo(dst []M) {
+	if d.length == 0 {
+		return
+	}
+	n := copy(dst, d.data[d.head:min(d.head+d.length, len(d.data))])
+	copy(dst[n:d.length], d.data)
+}
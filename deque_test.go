@@ -0,0 +1,133 @@
+package q
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDequePushPop(t *testing.T) {
+	assert := assert.New(t)
+	d := NewDeque[int]()
+	d.Push(1, 2, 3)
+	assert.Equal(3, d.Pop())
+	assert.Equal(2, d.Pop())
+	assert.Equal(1, d.Pop())
+	assert.Zero(d.Pop())
+
+	d.PushLeft(1, 2, 3)
+	assert.Equal(3, d.PeekLeft())
+	assert.Equal(1, d.PeekRight())
+	assert.Equal(3, d.PopLeft())
+	assert.Equal(2, d.PopLeft())
+	assert.Equal(1, d.PopLeft())
+	assert.Zero(d.PopLeft())
+	assert.Equal(0, d.Len())
+
+	_, ok := d.TryPopLeft()
+	assert.False(ok)
+	_, err := d.PopErr()
+	assert.ErrorIs(err, ErrEmpty)
+	_, err = d.PeekLeftErr()
+	assert.ErrorIs(err, ErrEmpty)
+	_, err = d.PeekRightErr()
+	assert.ErrorIs(err, ErrEmpty)
+}
+
+func TestDequeMatchesList(t *testing.T) {
+	assert := assert.New(t)
+	d := NewDeque[int]()
+	l := NewList[int]()
+	for i := 0; i < 10000; i++ {
+		switch rand.Intn(4) {
+		case 0:
+			d.PushLeft(i)
+			l.PushLeft(i)
+		case 1:
+			d.PushRight(i)
+			l.PushRight(i)
+		case 2:
+			assert.Equal(l.PopLeft(), d.PopLeft())
+		case 3:
+			assert.Equal(l.PopRight(), d.PopRight())
+		}
+		assert.Equal(l.Len(), d.Len())
+	}
+	assert.Equal(l.Elements(), d.Elements())
+}
+
+func TestDequeAt(t *testing.T) {
+	assert := assert.New(t)
+	d := NewDeque(2, 3)
+	d.PushLeft(1, 0)
+	for i := 0; i < 4; i++ {
+		value, err := d.At(i)
+		assert.NoError(err)
+		assert.Equal(i, value)
+	}
+	value, err := d.At(-1)
+	assert.NoError(err)
+	assert.Equal(3, value)
+	_, err = d.At(4)
+	assert.ErrorIs(err, ErrOutOfRange)
+
+	assert.NoError(d.SetAt(-4, 10))
+	assert.ErrorIs(d.SetAt(-5, 10), ErrOutOfRange)
+	assert.Equal([]int{10, 1, 2, 3}, d.Elements())
+}
+
+func TestDequeShrink(t *testing.T) {
+	assert := assert.New(t)
+	d := NewDeque[int]()
+	for i := 0; i < 1000; i++ {
+		d.PushRight(i)
+	}
+	assert.Equal(1024, d.Cap())
+	for i := 0; i < 990; i++ {
+		assert.Equal(i, d.PopLeft())
+	}
+	assert.Equal(10, d.Len())
+	assert.LessOrEqual(d.Cap(), 64)
+	assert.Equal([]int{990, 991, 992, 993, 994, 995, 996, 997, 998, 999}, d.Elements())
+
+	d.Clear()
+	assert.Equal(0, d.Len())
+	assert.Equal(0, d.Cap())
+}
+
+func TestDequeIterators(t *testing.T) {
+	assert := assert.New(t)
+	d := NewDeque(1, 2, 3)
+	assert.Equal([]int{1, 2, 3}, slices.Collect(d.Values()))
+
+	var indices []int
+	for i := range d.Backward() {
+		indices = append(indices, i)
+	}
+	assert.Equal([]int{2, 1, 0}, indices)
+
+	sum := 0
+	d.Each(func(i, value int) {
+		sum += value
+	})
+	assert.Equal(6, sum)
+	for i, value := range d.Iter() {
+		assert.Equal(i+1, value)
+	}
+	assert.Equal("[1 2 3]", d.String())
+}
+
+// ExampleDeque demonstrates how to use a deque as a queue.
+func ExampleDeque() {
+	d := NewDeque(1, 2)
+	d.PushRight(3)
+	d.PushLeft(0)
+	fmt.Println(d.PopLeft())
+	fmt.Println(d)
+	// Output:
+	// 0
+	// [1 2 3]
+}
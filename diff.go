@@ -0,0 +1,189 @@
+package q
+
+import (
+	"fmt"
+	"slices"
+)
+
+// EditOp is the kind of operation in an edit script.
+type EditOp int
+
+const (
+	// EditKeep keeps a value that is present in both lists.
+	EditKeep EditOp = iota
+	// EditDelete removes a value that is only present in the old list.
+	EditDelete
+	// EditInsert adds a value that is only present in the new list.
+	EditInsert
+)
+
+// String returns the name of the operation.
+func (op EditOp) String() string {
+	switch op {
+	case EditKeep:
+		return "keep"
+	case EditDelete:
+		return "delete"
+	case EditInsert:
+		return "insert"
+	}
+	return fmt.Sprintf("EditOp(%d)", int(op))
+}
+
+// Edit is a single operation in an edit script. OldIndex is the value's position in the old list and is -1
+// for insertions. NewIndex is the value's position in the new list and is -1 for deletions.
+type Edit[M any] struct {
+	Op       EditOp
+	OldIndex int
+	NewIndex int
+	Value    M
+}
+
+// String returns the edit in unified diff style: a space, "-" or "+" followed by the value.
+func (e Edit[M]) String() string {
+	switch e.Op {
+	case EditDelete:
+		return fmt.Sprintf("-%v", e.Value)
+	case EditInsert:
+		return fmt.Sprintf("+%v", e.Value)
+	}
+	return fmt.Sprintf(" %v", e.Value)
+}
+
+// Diff returns a shortest edit script that turns list a into list b, computed with Myers' algorithm.
+// Time complexity: O((n+m)d), where n and m are the lengths of the lists and d is the number of insertions and deletions.
+// It uses O(n+m+d²) additional memory.
+func Diff[M comparable](a, b *List[M]) []Edit[M] {
+	return DiffFunc(a, b, func(x, y M) bool {
+		return x == y
+	})
+}
+
+// DiffFunc is like Diff but compares values with the provided equal function.
+// Time complexity: O((n+m)d), where n and m are the lengths of the lists and d is the number of insertions and deletions.
+// It uses O(n+m+d²) additional memory.
+func DiffFunc[M any](a, b *List[M], equal func(M, M) bool) []Edit[M] {
+	source, target := a.Elements(), b.Elements()
+	n, m := len(source), len(target)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	// trace[d] holds the furthest x reached on each diagonal k = -d, -d+2, ..., d after d edits, at index (k+d)/2.
+	var trace [][]int
+search:
+	for d := 0; d <= n+m; d++ {
+		frontier := make([]int, d+1)
+		trace = append(trace, frontier)
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && equal(source[x], target[y]) {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			frontier[(k+d)/2] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	var script []Edit[M]
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		previous := trace[d-1]
+		at := func(k int) int {
+			return previous[(k+d-1)/2]
+		}
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			script = append(script, Edit[M]{Op: EditKeep, OldIndex: x, NewIndex: y, Value: source[x]})
+		}
+		if x == prevX {
+			script = append(script, Edit[M]{Op: EditInsert, OldIndex: -1, NewIndex: prevY, Value: target[prevY]})
+		} else {
+			script = append(script, Edit[M]{Op: EditDelete, OldIndex: prevX, NewIndex: -1, Value: source[prevX]})
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 {
+		x--
+		y--
+		script = append(script, Edit[M]{Op: EditKeep, OldIndex: x, NewIndex: y, Value: source[x]})
+	}
+	slices.Reverse(script)
+	return script
+}
+
+// Patch applies an edit script produced by Diff or DiffFunc to a list in place.
+// It returns ErrPatch without modifying the list if the script does not match the list's length.
+// Time complexity: O(n+e), or O(n·e) with observers registered, where n is the number of elements in the list and
+// e is the number of edits.
+func Patch[M any](l *List[M], script []Edit[M]) error {
+	consumed := 0
+	for _, edit := range script {
+		if edit.Op == EditInsert {
+			continue
+		}
+		if edit.OldIndex != consumed {
+			return fmt.Errorf("%w: %v at old index %d, expected %d", ErrPatch, edit.Op, edit.OldIndex, consumed)
+		}
+		consumed++
+	}
+	if consumed != l.Len() {
+		return fmt.Errorf("%w: script covers %d values but list has %d", ErrPatch, consumed, l.Len())
+	}
+	cursor := l.head
+	for _, edit := range script {
+		switch edit.Op {
+		case EditKeep:
+			cursor = cursor.next
+		case EditDelete:
+			next := cursor.next
+			l.unlink(cursor)
+			cursor = next
+		case EditInsert:
+			prev := l.tail
+			if cursor != nil {
+				prev = cursor.prev
+			}
+			l.insert(&Node[M]{value: edit.Value}, prev, cursor)
+		}
+	}
+	l.trim(true)
+	l.check()
+	return nil
+}
+
+// EqualFunc returns true if the two lists have the same length and their values are equal according to the
+// provided equal function, false otherwise.
+// Time complexity: O(n), where n is the number of elements in the list.
+func EqualFunc[M any](a, b *List[M], equal func(M, M) bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.length != b.length {
+		return false
+	}
+	for na, nb := a.head, b.head; na != nil && nb != nil; na, nb = na.next, nb.next {
+		if !equal(na.value, nb.value) {
+			return false
+		}
+	}
+	return true
+}
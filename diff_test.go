@@ -0,0 +1,130 @@
+package q
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	assert := assert.New(t)
+	a := NewList(strings.Split("ABCABBA", "")...)
+	b := NewList(strings.Split("CBABAC", "")...)
+	script := Diff(a, b)
+
+	changes := 0
+	for _, edit := range script {
+		if edit.Op != EditKeep {
+			changes++
+		}
+	}
+	assert.Equal(5, changes)
+	assert.Equal(EditDelete, script[0].Op)
+	assert.Equal(0, script[0].OldIndex)
+	assert.Equal(-1, script[0].NewIndex)
+
+	assert.NoError(Patch(a, script))
+	assert.Equal(b.Elements(), a.Elements())
+	assert.Equal(b.Len(), a.Len())
+}
+
+func TestDiffEdgeCases(t *testing.T) {
+	assert := assert.New(t)
+	assert.Empty(Diff(NewList[int](), NewList[int]()))
+
+	inserts := Diff(NewList[int](), NewList(1, 2))
+	assert.Equal([]Edit[int]{
+		{Op: EditInsert, OldIndex: -1, NewIndex: 0, Value: 1},
+		{Op: EditInsert, OldIndex: -1, NewIndex: 1, Value: 2},
+	}, inserts)
+
+	keeps := Diff(NewList(1, 2), NewList(1, 2))
+	assert.Equal([]Edit[int]{
+		{Op: EditKeep, OldIndex: 0, NewIndex: 0, Value: 1},
+		{Op: EditKeep, OldIndex: 1, NewIndex: 1, Value: 2},
+	}, keeps)
+}
+
+func TestDiffRandom(t *testing.T) {
+	assert := assert.New(t)
+	for i := 0; i < 100; i++ {
+		a, b := NewList[int](), NewList[int]()
+		for j := rand.Intn(30); j > 0; j-- {
+			a.PushRight(rand.Intn(5))
+		}
+		for j := rand.Intn(30); j > 0; j-- {
+			b.PushRight(rand.Intn(5))
+		}
+		script := Diff(a, b)
+		changes := 0
+		for _, edit := range script {
+			if edit.Op != EditKeep {
+				changes++
+			}
+		}
+		assert.Equal(a.Len()+b.Len()-2*lcsLength(a.Elements(), b.Elements()), changes)
+		assert.NoError(Patch(a, script))
+		assert.True(Equal(a, b), "%v != %v", a, b)
+	}
+}
+
+// lcsLength returns the length of the longest common subsequence of a and b.
+func lcsLength(a, b []int) int {
+	table := make([][]int, len(a)+1)
+	for i := range table {
+		table[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else {
+				table[i][j] = max(table[i+1][j], table[i][j+1])
+			}
+		}
+	}
+	return table[0][0]
+}
+
+func TestDiffFunc(t *testing.T) {
+	assert := assert.New(t)
+	a := NewList([]int{1}, []int{2})
+	b := NewList([]int{1}, []int{3})
+	equal := func(x, y []int) bool {
+		return x[0] == y[0]
+	}
+	assert.False(EqualFunc(a, b, equal))
+	assert.True(EqualFunc(a, a.Copy(), equal))
+	assert.False(EqualFunc(a, nil, equal))
+
+	script := DiffFunc(a, b, equal)
+	assert.Len(script, 3)
+	assert.NoError(Patch(a, script))
+	assert.True(EqualFunc(a, b, equal))
+}
+
+func TestPatchMismatch(t *testing.T) {
+	assert := assert.New(t)
+	script := Diff(NewList(1, 2, 3), NewList(1, 3))
+	l := NewList(1, 2)
+	assert.ErrorIs(Patch(l, script), ErrPatch)
+	assert.Equal([]int{1, 2}, l.Elements())
+	assert.ErrorIs(Patch(l, script[1:]), ErrPatch)
+}
+
+// ExampleDiff demonstrates how to print a readable diff between two lists.
+func ExampleDiff() {
+	a := NewList("apple", "banana", "cherry")
+	b := NewList("apple", "cherry", "date")
+	for _, edit := range Diff(a, b) {
+		fmt.Println(edit)
+	}
+	// Output:
+	//  apple
+	// -banana
+	//  cherry
+	// +date
+}
@@ -0,0 +1,54 @@
+package q
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrEmpty is returned when a value is requested from an empty collection.
+var ErrEmpty = errors.New("q: collection is empty")
+
+// ErrOutOfRange is returned when an index is outside the bounds of a collection.
+var ErrOutOfRange = errors.New("q: index out of range")
+
+// ErrSameList is returned when a list is spliced into itself.
+var ErrSameList = errors.New("q: cannot splice a list into itself")
+
+// ErrClosed is returned when putting a value into a closed queue, or taking a value from a closed and drained queue.
+var ErrClosed = errors.New("q: queue is closed")
+
+// ErrFull is returned when a value cannot be put into a queue because it is at capacity.
+var ErrFull = errors.New("q: queue is full")
+
+// ErrInvalidEncoding is returned when decoding binary data that was not written by the same kind of collection.
+var ErrInvalidEncoding = errors.New("q: invalid binary encoding")
+
+// ErrPatch is returned when an edit script does not apply to a list.
+var ErrPatch = errors.New("q: edit script does not match list")
+
+// ErrCorrupt is returned by Validate when a collection's internal invariants do not hold.
+var ErrCorrupt = errors.New("q: collection is corrupt")
+
+// ErrTxDone is returned when committing or rolling back a transaction that has already finished.
+var ErrTxDone = errors.New("q: transaction has already been committed or rolled back")
+
+// ErrTxConflict is returned when rolling back a transaction whose nodes were changed by another list since.
+var ErrTxConflict = errors.New("q: transaction cannot be rolled back because its nodes were changed by another list")
+
+// outOfRange returns an ErrOutOfRange error describing the index and length.
+func outOfRange(i, length int) error {
+	return fmt.Errorf("%w: index %d with length %d", ErrOutOfRange, i, length)
+}
+
+// resolveIndex resolves a possibly negative index against a length. An index equal to the length is only valid if
+// end is true. The error reports the index as the caller passed it.
+func resolveIndex(i, length int, end bool) (int, error) {
+	j := i
+	if j < 0 {
+		j += length
+	}
+	if j < 0 || j > length || (j == length && !end) {
+		return 0, outOfRange(i, length)
+	}
+	return j, nil
+}
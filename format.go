@@ -0,0 +1,195 @@
+package q
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Format implements fmt.Formatter. %v writes the values in order, %+v adds the length
+// and %#v writes a Go-syntax constructor call. A precision such as %.5v limits the number of elements written,
+// replacing the rest with an ellipsis; the same applies to the other collections.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (l *List[M]) Format(f fmt.State, verb rune) {
+	elements := l.Elements()
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		writeConstructor(f, "q.NewList", "", elements)
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprintf(f, "len=%d ", l.Len())
+		writeBracketed(f, verb, elements)
+	default:
+		writeBracketed(f, verb, elements)
+	}
+}
+
+// Format implements fmt.Formatter. %v writes the elements, sorted if they are of an ordered type,
+// %+v adds the length and %#v writes a Go-syntax constructor call. Sorting happens before the precision limit is
+// applied, so it costs O(n log n) even when only a few elements are written.
+// Time complexity: O(n log n), where n is the number of elements in the set.
+func (s *Set[T]) Format(f fmt.State, verb rune) {
+	elements := s.Elements()
+	sortOrdered(elements)
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		writeConstructor(f, "q.NewSet", "", elements)
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprintf(f, "len=%d ", s.Len())
+		writeBracketed(f, verb, elements)
+	default:
+		writeBracketed(f, verb, elements)
+	}
+}
+
+// Format implements fmt.Formatter. %v writes the elements and their counts, sorted if the elements are of an
+// ordered type, %+v adds the total and unique counts and %#v writes a Go-syntax constructor call.
+// Time complexity: O(u log u), or O(u log u + n) for %#v, which repeats each element by its count, where u is the
+// number of unique elements and n is the total count.
+func (c *Counter[T]) Format(f fmt.State, verb rune) {
+	elements := c.Elements()
+	sortOrdered(elements)
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		var repeated []T
+		for _, element := range elements {
+			for i := 0; i < c.data[element]; i++ {
+				repeated = append(repeated, element)
+			}
+		}
+		writeConstructor(f, "q.NewCounter", "", repeated)
+		return
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprintf(f, "len=%d unique=%d ", c.Len(), len(elements))
+	}
+	format := elementFormat(f, verb)
+	limit := formatLimit(f)
+	fmt.Fprint(f, "map[")
+	for i, element := range elements {
+		if i > 0 {
+			fmt.Fprint(f, " ")
+		}
+		if limit > 0 && i == limit {
+			fmt.Fprint(f, "...")
+			break
+		}
+		fmt.Fprintf(f, format+":%d", element, c.data[element])
+	}
+	fmt.Fprint(f, "]")
+}
+
+// Format implements fmt.Formatter. %v writes the elements in heap order, %+v adds the length and the top element
+// and %#v writes a Go-syntax constructor call with a placeholder for the less function.
+// Time complexity: O(n), where n is the number of elements in the heap.
+func (h *Heap[M]) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		writeConstructor(f, "q.NewHeap", "less", h.data)
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprintf(f, "len=%d ", h.Len())
+		if top, ok := h.TryTop(); ok {
+			fmt.Fprintf(f, "top=%+v ", top)
+		}
+		writeBracketed(f, verb, h.data)
+	default:
+		writeBracketed(f, verb, h.data)
+	}
+}
+
+// formatLimit returns the maximum number of elements to write, taken from the precision. Zero means no limit.
+// Time complexity: O(1).
+func formatLimit(f fmt.State) int {
+	precision, _ := f.Precision()
+	return precision
+}
+
+// elementFormat returns the format string for a single element, keeping the flags and width but not the precision,
+// which sets the element limit instead.
+// Time complexity: O(1).
+func elementFormat(f fmt.State, verb rune) string {
+	var b strings.Builder
+	b.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			b.WriteRune(flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		fmt.Fprint(&b, width)
+	}
+	b.WriteRune(verb)
+	return b.String()
+}
+
+// writeBracketed writes the elements separated by spaces inside square brackets.
+// Time complexity: O(n), where n is the number of elements.
+func writeBracketed[M any](f fmt.State, verb rune, elements []M) {
+	format := elementFormat(f, verb)
+	limit := formatLimit(f)
+	fmt.Fprint(f, "[")
+	for i, element := range elements {
+		if i > 0 {
+			fmt.Fprint(f, " ")
+		}
+		if limit > 0 && i == limit {
+			fmt.Fprint(f, "...")
+			break
+		}
+		fmt.Fprintf(f, format, element)
+	}
+	fmt.Fprint(f, "]")
+}
+
+// writeConstructor writes a Go-syntax call to the named constructor with the leading arguments and the elements.
+// The element type is written explicitly when there are no elements to infer it from.
+// Time complexity: O(n), where n is the number of elements.
+func writeConstructor[M any](f fmt.State, name, leading string, elements []M) {
+	fmt.Fprint(f, name)
+	if len(elements) == 0 {
+		fmt.Fprintf(f, "[%v]", reflect.TypeFor[M]())
+	}
+	fmt.Fprint(f, "(", leading)
+	limit := formatLimit(f)
+	for i, element := range elements {
+		if i > 0 || leading != "" {
+			fmt.Fprint(f, ", ")
+		}
+		if limit > 0 && i == limit {
+			fmt.Fprint(f, "...")
+			break
+		}
+		fmt.Fprintf(f, "%#v", element)
+	}
+	fmt.Fprint(f, ")")
+}
+
+// sortOrdered sorts the elements in place if their underlying type is an integer, float or string type.
+// Other element types are left as they are.
+// Time complexity: O(n log n), where n is the number of elements.
+func sortOrdered[M any](elements []M) {
+	var compare func(a, b reflect.Value) int
+	switch reflect.TypeFor[M]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		compare = func(a, b reflect.Value) int {
+			return cmp.Compare(a.Int(), b.Int())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		compare = func(a, b reflect.Value) int {
+			return cmp.Compare(a.Uint(), b.Uint())
+		}
+	case reflect.Float32, reflect.Float64:
+		compare = func(a, b reflect.Value) int {
+			return cmp.Compare(a.Float(), b.Float())
+		}
+	case reflect.String:
+		compare = func(a, b reflect.Value) int {
+			return cmp.Compare(a.String(), b.String())
+		}
+	default:
+		return
+	}
+	slices.SortFunc(elements, func(a, b M) int {
+		return compare(reflect.ValueOf(a), reflect.ValueOf(b))
+	})
+}
@@ -0,0 +1,51 @@
+package q
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDeterministic(t *testing.T) {
+	assert := assert.New(t)
+	set := NewSet(5, 3, 9, 1, 7)
+	for i := 0; i < 10; i++ {
+		assert.Equal("[1 3 5 7 9]", set.String())
+	}
+	assert.Equal("map[a:1 b:2 c:1]", NewCounter("c", "b", "a", "b").String())
+	assert.Equal("[1 2 3]", fmt.Sprint(NewList(1, 2, 3)))
+	assert.Equal("[3 5 7]", fmt.Sprint(NewMinHeap(5, 3, 7)))
+	assert.Equal("[03 10]", fmt.Sprintf("%02d", NewList(3, 10)))
+	assert.Equal(`["a" "b"]`, fmt.Sprintf("%q", NewSet("b", "a")))
+}
+
+func TestFormatMetadata(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("len=3 [1 2 3]", fmt.Sprintf("%+v", NewList(1, 2, 3)))
+	assert.Equal("len=2 [1 2]", fmt.Sprintf("%+v", NewSet(2, 1)))
+	assert.Equal("len=3 unique=2 map[a:2 b:1]", fmt.Sprintf("%+v", NewCounter("a", "b", "a")))
+	assert.Equal("len=3 top=3 [3 5 7]", fmt.Sprintf("%+v", NewMinHeap(5, 3, 7)))
+	assert.Equal("len=0 []", fmt.Sprintf("%+v", NewMinHeap[int]()))
+}
+
+func TestFormatGoSyntax(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("q.NewList(1, 2, 3)", fmt.Sprintf("%#v", NewList(1, 2, 3)))
+	assert.Equal("q.NewList[int]()", fmt.Sprintf("%#v", NewList[int]()))
+	assert.Equal(`q.NewSet("a", "b")`, fmt.Sprintf("%#v", NewSet("b", "a")))
+	assert.Equal(`q.NewCounter("a", "a", "b")`, fmt.Sprintf("%#v", NewCounter("b", "a", "a")))
+	assert.Equal("q.NewHeap(less, 3, 5)", fmt.Sprintf("%#v", NewMinHeap(5, 3)))
+	assert.Equal("q.NewHeap[string](less)", fmt.Sprintf("%#v", NewMinHeap[string]()))
+}
+
+func TestFormatLimit(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2, 3, 4, 5)
+	assert.Equal("[1 2 ...]", fmt.Sprintf("%.2v", l))
+	assert.Equal("q.NewList(1, 2, ...)", fmt.Sprintf("%#.2v", l))
+	assert.Equal("map[a:1 ...]", fmt.Sprintf("%.1v", NewCounter("a", "b")))
+	assert.Equal("[1 2 3 4 5]", fmt.Sprintf("%.0v", l))
+	assert.Equal("[1 2 3 4 5]", l.String())
+	assert.Equal("[1 2]", fmt.Sprintf("%.3v", NewList(1, 2)))
+}
@@ -1,11 +1,16 @@
 package q
 
-import "fmt"
+import (
+	"cmp"
+	"fmt"
+	"iter"
+)
 
 // Heap is a generic implementation of a heap data structure.
 type Heap[M any] struct {
-	less func(a, b M) bool
-	data []M
+	less      func(a, b M) bool
+	data      []M
+	observers observerList[M]
 }
 
 // NewHeap creates a new instance of Heap with the specified less function.
@@ -16,27 +21,60 @@ func NewHeap[M any](less func(a, b M) bool, elements ...M) *Heap[M] {
 	return heap
 }
 
+// NewMinHeap creates a new Heap of ordered values whose top is the smallest value.
+// Time complexity: O(n log n), where n is the number of elements.
+func NewMinHeap[T cmp.Ordered](elements ...T) *Heap[T] {
+	return NewHeap(Less[T], elements...)
+}
+
+// NewMaxHeap creates a new Heap of ordered values whose top is the largest value.
+// Time complexity: O(n log n), where n is the number of elements.
+func NewMaxHeap[T cmp.Ordered](elements ...T) *Heap[T] {
+	return NewHeap(Greater[T], elements...)
+}
+
 // Push adds one or more values to the heap.
 // Time complexity: O(log n) for each value, where n is the number of elements in the heap.
 func (h *Heap[M]) Push(values ...M) {
 	for _, value := range values {
 		h.data = append(h.data, value)
 		h.up(len(h.data) - 1)
+		h.observers.emit(Event[M]{Kind: EventInserted, Value: value, Index: -1})
 	}
+	h.check()
 }
 
 // Pop removes and returns the top element from the heap.
 // Time complexity: O(log n), where n is the number of elements in the heap.
 func (h *Heap[M]) Pop() M {
+	value, _ := h.TryPop()
+	return value
+}
+
+// TryPop removes and returns the top element from the heap. It returns false if the heap is empty.
+// Time complexity: O(log n), where n is the number of elements in the heap.
+func (h *Heap[M]) TryPop() (M, bool) {
 	if len(h.data) == 0 {
 		var m M
-		return m
+		return m, false
 	}
 	h.swap(0, len(h.data)-1)
 	value := h.data[len(h.data)-1]
 	h.data = h.data[:len(h.data)-1]
 	h.down(0)
-	return value
+	h.observers.emit(Event[M]{Kind: EventRemoved, Value: value, Index: -1})
+	h.check()
+	return value, true
+}
+
+// PopErr removes and returns the top element from the heap. It returns ErrEmpty if the heap is empty.
+// Time complexity: O(log n), where n is the number of elements in the heap.
+func (h *Heap[M]) PopErr() (M, error) {
+	value, ok := h.TryPop()
+	if !ok {
+		return value, ErrEmpty
+	}
+	return value, nil
 }
 
 // Len returns the number of elements in the heap.
@@ -48,11 +86,28 @@ func (h *Heap[M]) Len() int {
 // Top returns the top element of the heap without removing it.
 // Time complexity: O(1).
 func (h *Heap[M]) Top() M {
+	value, _ := h.TryTop()
+	return value
+}
+
+// TryTop returns the top element of the heap without removing it. It returns false if the heap is empty.
+// Time complexity: O(1).
+func (h *Heap[M]) TryTop() (M, bool) {
 	if len(h.data) == 0 {
 		var m M
-		return m
+		return m, false
+	}
+	return h.data[0], true
+}
+
+// TopErr returns the top element of the heap without removing it. It returns ErrEmpty if the heap is empty.
+// Time complexity: O(1).
+func (h *Heap[M]) TopErr() (M, error) {
+	value, ok := h.TryTop()
+	if !ok {
+		return value, ErrEmpty
 	}
-	return h.data[0]
+	return value, nil
 }
 
 // Empty returns true if the heap is empty, false otherwise.
@@ -61,6 +116,60 @@ func (h *Heap[M]) Empty() bool {
 	return len(h.data) == 0
 }
 
+// All returns an iterator over the elements in the heap in priority order, without removing them.
+// Time complexity: O(k log k) to yield the first k elements.
+func (h *Heap[M]) All() iter.Seq[M] {
+	return func(yield func(M) bool) {
+		if len(h.data) == 0 {
+			return
+		}
+		// frontier holds the indices of the candidates for the next element, ordered as a heap.
+		frontier := NewHeap(func(a, b int) bool {
+			return h.less(h.data[a], h.data[b])
+		}, 0)
+		for !frontier.Empty() {
+			i := frontier.Pop()
+			if !yield(h.data[i]) {
+				return
+			}
+			for _, child := range []int{2*i + 1, 2*i + 2} {
+				if child < len(h.data) {
+					frontier.Push(child)
+				}
+			}
+		}
+	}
+}
+
+// Stream returns a lazy stream over the elements in the heap in priority order, without removing them.
+// Time complexity: O(1).
+func (h *Heap[M]) Stream() *Stream[M] {
+	return NewStream(h.All())
+}
+
+// heapify restores the heap property for all the elements.
+// Time complexity: O(n), where n is the number of elements in the heap.
+func (h *Heap[M]) heapify() {
+	for i := len(h.data)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	h.check()
+}
+
+// reset replaces the contents of the heap with the values and restores the heap property, reporting the change
+// to observers as a clear followed by an insertion for each value.
+// Time complexity: O(n), where n is the number of values.
+func (h *Heap[M]) reset(values []M) {
+	h.data = values
+	h.heapify()
+	if len(h.observers) > 0 {
+		h.observers.emit(Event[M]{Kind: EventCleared, Index: -1})
+		for _, value := range h.data {
+			h.observers.emit(Event[M]{Kind: EventInserted, Value: value, Index: -1})
+		}
+	}
+}
+
 // up moves the element at index i up the heap until the heap property is satisfied.
 // Time complexity: O(log n), where n is the number of elements in the heap.
 func (h *Heap[M]) up(i int) {
@@ -101,5 +210,5 @@ func (h *Heap[M]) swap(i, j int) {
 
 // String returns a string representation of the heap.
 func (h *Heap[M]) String() string {
-	return fmt.Sprintf("%v", h.data)
+	return fmt.Sprintf("%v", h)
 }
@@ -3,6 +3,7 @@ package q
 import (
 	"fmt"
 	"math/rand"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -58,6 +59,52 @@ func TestHeapWithRandom(t *testing.T) {
 	assert.True(h.Empty())
 }
 
+func TestHeapTryPop(t *testing.T) {
+	assert := assert.New(t)
+	h := NewHeap(func(a, b int) bool {
+		return a < b
+	}, 0)
+	value, ok := h.TryTop()
+	assert.True(ok)
+	assert.Equal(0, value)
+	value, err := h.PopErr()
+	assert.NoError(err)
+	assert.Equal(0, value)
+
+	_, ok = h.TryPop()
+	assert.False(ok)
+	_, ok = h.TryTop()
+	assert.False(ok)
+	_, err = h.TopErr()
+	assert.ErrorIs(err, ErrEmpty)
+	_, err = h.PopErr()
+	assert.ErrorIs(err, ErrEmpty)
+}
+
+func TestHeapAll(t *testing.T) {
+	assert := assert.New(t)
+	h := NewHeap(func(a, b int) bool {
+		return a < b
+	})
+	for i := 0; i < 1000; i++ {
+		h.Push(rand.Intn(100))
+	}
+	values := slices.Collect(h.All())
+	assert.Len(values, 1000)
+	assert.True(slices.IsSorted(values))
+	assert.Equal(1000, h.Len())
+
+	var first []int
+	for v := range h.All() {
+		if len(first) == 3 {
+			break
+		}
+		first = append(first, v)
+	}
+	assert.Equal(values[:3], first)
+	assert.Empty(slices.Collect(NewHeap(func(a, b int) bool { return a < b }).All()))
+}
+
 // ExampleHeap_Push demonstrates how to push elements onto the heap.
 func ExampleHeap_Push() {
 	h := NewHeap(func(a, b int) bool {
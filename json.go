@@ -0,0 +1,209 @@
+package q
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// jsonNull reports whether data is the JSON literal null, which decoding treats as a no-op like encoding/json does.
+// Time complexity: O(n), where n is the length of data.
+func jsonNull(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+}
+
+// requireLess returns an error if the heap has no less function to restore the heap property with after decoding.
+// Time complexity: O(1).
+func (h *Heap[M]) requireLess() error {
+	if h == nil || h.less == nil {
+		return fmt.Errorf("q: cannot decode a Heap[%v] without a less function; create it with NewHeap first", reflect.TypeFor[M]())
+	}
+	return nil
+}
+
+// MarshalJSON encodes the list as a JSON array.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (l *List[M]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.Elements())
+}
+
+// UnmarshalJSON replaces the contents of the list with the values of a JSON array. A JSON null leaves it unchanged.
+// Time complexity: O(n + m), where n is the number of elements in the list and m is the number of values decoded.
+func (l *List[M]) UnmarshalJSON(data []byte) error {
+	if jsonNull(data) {
+		return nil
+	}
+	var values []M
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	l.Clear()
+	l.PushRight(values...)
+	return nil
+}
+
+// MarshalOrder sets the order in which the set's elements are encoded. A nil less function encodes them in no
+// particular order, which is the default.
+// Time complexity: O(1).
+func (s *Set[T]) MarshalOrder(less func(a, b T) bool) {
+	s.order = less
+}
+
+// orderedElements returns the set's elements in the order set by MarshalOrder.
+// Time complexity: O(n log n) with an order set and O(n) otherwise, where n is the number of elements in the set.
+func (s *Set[T]) orderedElements() []T {
+	elements := s.Elements()
+	if s.order != nil {
+		slices.SortStableFunc(elements, func(a, b T) int {
+			switch {
+			case s.order(a, b):
+				return -1
+			case s.order(b, a):
+				return 1
+			}
+			return 0
+		})
+	}
+	return elements
+}
+
+// MarshalJSON encodes the set as a JSON array.
+// Time complexity: O(n log n) with an order set by MarshalOrder and O(n) otherwise, where n is the number of
+// elements in the set.
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.orderedElements())
+}
+
+// UnmarshalJSON replaces the contents of the set with the values of a JSON array. A JSON null leaves it unchanged.
+// Time complexity: O(m), where m is the number of values decoded.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	if jsonNull(data) {
+		return nil
+	}
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	s.Clear()
+	s.Add(values...)
+	return nil
+}
+
+// MarshalJSON encodes the counter as a JSON object from elements to counts if its elements can be object keys,
+// and as a JSON array of [element, count] pairs otherwise.
+// Time complexity: O(n), where n is the number of unique elements in the counter.
+func (c *Counter[T]) MarshalJSON() ([]byte, error) {
+	if jsonKey[T]() {
+		if c.data == nil {
+			return []byte("{}"), nil
+		}
+		return json.Marshal(c.data)
+	}
+	pairs := make([][2]any, 0, len(c.data))
+	for element, count := range c.data {
+		pairs = append(pairs, [2]any{element, count})
+	}
+	return json.Marshal(pairs)
+}
+
+// UnmarshalJSON replaces the contents of the counter with the counts of a JSON object or array of [element, count] pairs.
+// A JSON null leaves it unchanged.
+// Time complexity: O(m), where m is the number of elements decoded.
+func (c *Counter[T]) UnmarshalJSON(data []byte) error {
+	if jsonNull(data) {
+		return nil
+	}
+	counts := make(map[T]int)
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		if err := json.Unmarshal(data, &counts); err != nil {
+			return err
+		}
+	} else {
+		var pairs [][2]json.RawMessage
+		if err := json.Unmarshal(data, &pairs); err != nil {
+			return err
+		}
+		for _, pair := range pairs {
+			var element T
+			var count int
+			if err := json.Unmarshal(pair[0], &element); err != nil {
+				return err
+			}
+			if err := json.Unmarshal(pair[1], &count); err != nil {
+				return err
+			}
+			counts[element] += count
+		}
+	}
+	for element, count := range counts {
+		if count < 1 {
+			return fmt.Errorf("q: invalid count %d for counter element %v", count, element)
+		}
+	}
+	elements := make([]T, 0, len(counts))
+	values := make([]int, 0, len(counts))
+	for element, count := range counts {
+		elements = append(elements, element)
+		values = append(values, count)
+	}
+	c.Clear()
+	c.addCounts(elements, values)
+	return nil
+}
+
+// jsonKey reports whether values of type T can be encoded as JSON object keys.
+// Time complexity: O(1).
+func jsonKey[T any]() bool {
+	t := reflect.TypeFor[T]()
+	if t.Implements(reflect.TypeFor[encoding.TextMarshaler]()) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+// MarshalJSON encodes the heap as a JSON array.
+// Time complexity: O(n), where n is the number of elements in the heap.
+func (h *Heap[M]) MarshalJSON() ([]byte, error) {
+	if h.data == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(h.data)
+}
+
+// UnmarshalJSON replaces the contents of the heap with the values of a JSON array and restores the heap property.
+// The heap must have been created with NewHeap; use UnmarshalHeapJSON to decode into a new heap instead.
+// A JSON null leaves it unchanged.
+// Time complexity: O(m), where m is the number of values decoded.
+func (h *Heap[M]) UnmarshalJSON(data []byte) error {
+	if jsonNull(data) {
+		return nil
+	}
+	if err := h.requireLess(); err != nil {
+		return err
+	}
+	var values []M
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	h.reset(values)
+	return nil
+}
+
+// UnmarshalHeapJSON decodes a JSON array into a new Heap ordered by the less function.
+// Time complexity: O(n), where n is the number of values.
+func UnmarshalHeapJSON[M any](data []byte, less func(a, b M) bool) (*Heap[M], error) {
+	h := NewHeap(less)
+	if err := h.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
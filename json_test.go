@@ -0,0 +1,124 @@
+package q
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListJSON(t *testing.T) {
+	assert := assert.New(t)
+	type payload struct {
+		Items *List[int] `json:"items"`
+	}
+	data, err := json.Marshal(payload{Items: NewList(1, 2, 3)})
+	assert.NoError(err)
+	assert.JSONEq(`{"items":[1,2,3]}`, string(data))
+
+	var decoded payload
+	assert.NoError(json.Unmarshal(data, &decoded))
+	assert.Equal([]int{1, 2, 3}, decoded.Items.Elements())
+	assert.Equal(3, decoded.Items.Len())
+
+	l := NewList(9)
+	assert.NoError(json.Unmarshal([]byte(`[4,5]`), l))
+	assert.Equal([]int{4, 5}, l.Elements())
+	assert.Error(json.Unmarshal([]byte(`{}`), l))
+}
+
+func TestSetJSON(t *testing.T) {
+	assert := assert.New(t)
+	set := NewSet("c", "a", "b")
+	set.MarshalOrder(Less[string])
+	data, err := json.Marshal(set)
+	assert.NoError(err)
+	assert.Equal(`["a","b","c"]`, string(data))
+
+	decoded := NewSet[string]()
+	assert.NoError(json.Unmarshal(data, decoded))
+	assert.ElementsMatch([]string{"a", "b", "c"}, decoded.Elements())
+
+	var zero Set[string]
+	assert.NoError(json.Unmarshal([]byte(`["x","x"]`), &zero))
+	assert.Equal(1, zero.Len())
+}
+
+func TestCounterJSON(t *testing.T) {
+	assert := assert.New(t)
+	counter := NewCounter("a", "b", "a")
+	data, err := json.Marshal(counter)
+	assert.NoError(err)
+	assert.JSONEq(`{"a":2,"b":1}`, string(data))
+
+	decoded := NewCounter[string]()
+	assert.NoError(json.Unmarshal(data, decoded))
+	assert.True(counter.Equal(decoded))
+
+	type point struct{ X, Y int }
+	points := NewCounter(point{1, 2}, point{1, 2})
+	data, err = json.Marshal(points)
+	assert.NoError(err)
+	assert.JSONEq(`[[{"X":1,"Y":2},2]]`, string(data))
+
+	var decodedPoints Counter[point]
+	assert.NoError(json.Unmarshal(data, &decodedPoints))
+	assert.True(points.Equal(&decodedPoints))
+
+	assert.Error(json.Unmarshal([]byte(`{"a":0}`), decoded))
+	assert.True(counter.Equal(decoded))
+}
+
+func TestHeapJSON(t *testing.T) {
+	assert := assert.New(t)
+	h := NewMaxHeap(1, 5, 3)
+	data, err := json.Marshal(h)
+	assert.NoError(err)
+
+	decoded := NewMaxHeap[int]()
+	assert.NoError(json.Unmarshal([]byte(`[1,2,3,4,5]`), decoded))
+	assert.Equal(5, decoded.Pop())
+	assert.Equal(4, decoded.Pop())
+
+	type payload struct {
+		Queue *Heap[float64] `json:"queue"`
+	}
+	var p payload
+	assert.Error(json.Unmarshal([]byte(`{"queue":[2,1]}`), &p))
+	p.Queue = NewMinHeap[float64]()
+	assert.NoError(json.Unmarshal([]byte(`{"queue":[2,1,3]}`), &p))
+	assert.Equal(1.0, p.Queue.Pop())
+
+	fromJSON, err := UnmarshalHeapJSON([]byte(`[2,1,3]`), Greater[int])
+	assert.NoError(err)
+	assert.Equal(3, fromJSON.Top())
+	_, err = UnmarshalHeapJSON([]byte(`{}`), Greater[int])
+	assert.Error(err)
+
+	roundTrip := NewMaxHeap[int]()
+	assert.NoError(json.Unmarshal(data, roundTrip))
+	assert.Equal(5, roundTrip.Top())
+	assert.Equal(3, roundTrip.Len())
+}
+
+func TestJSONEmptyAndNull(t *testing.T) {
+	assert := assert.New(t)
+	for _, value := range []any{&Heap[int]{}, NewMinHeap[int](), &Counter[string]{}, NewCounter[string](), NewList[int](), NewSet[int]()} {
+		data, err := json.Marshal(value)
+		assert.NoError(err)
+		assert.NotEqual("null", string(data))
+	}
+
+	l := NewList(1, 2)
+	assert.NoError(json.Unmarshal([]byte(`null`), l))
+	assert.Equal([]int{1, 2}, l.Elements())
+	s := NewSet(1)
+	assert.NoError(json.Unmarshal([]byte(` null `), s))
+	assert.Equal(1, s.Len())
+	c := NewCounter("a")
+	assert.NoError(json.Unmarshal([]byte(`null`), c))
+	assert.Equal(1, c.Len())
+	h := NewMinHeap(1)
+	assert.NoError(json.Unmarshal([]byte(`null`), h))
+	assert.Equal(1, h.Len())
+}
@@ -1,12 +1,24 @@
 package q
 
-import "fmt"
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"runtime"
+	"slices"
+	"sync"
+)
 
 // List represents a generic linked list.
 type List[M any] struct {
-	length int64
-	head   *Node[M]
-	tail   *Node[M]
+	length    int64
+	head      *Node[M]
+	tail      *Node[M]
+	maxLen    int
+	onEvict   func(M)
+	tx        *txLog
+	observers observerList[M]
 }
 
 // Node represents a node in the linked list.
@@ -14,18 +26,64 @@ type Node[M any] struct {
 	value M
 	next  *Node[M]
 	prev  *Node[M]
+	list  *List[M]
+}
+
+// Value returns the value stored in the node.
+// Time complexity: O(1).
+func (n *Node[M]) Value() M {
+	return n.value
+}
+
+// Next returns the next node in the list, or nil if n is the last node.
+// Time complexity: O(1).
+func (n *Node[M]) Next() *Node[M] {
+	if n.list == nil {
+		return nil
+	}
+	return n.next
+}
+
+// Prev returns the previous node in the list, or nil if n is the first node.
+// Time complexity: O(1).
+func (n *Node[M]) Prev() *Node[M] {
+	if n.list == nil {
+		return nil
+	}
+	return n.prev
 }
 
 // NewList creates a new List and initializes it with the given elements.
 // Time complexity: O(n), where n is the number of elements.
 func NewList[M any](elements ...M) *List[M] {
 	l := &List[M]{}
-	for _, e := range elements {
-		l.PushRight(e)
-	}
+	l.PushRight(elements...)
 	return l
 }
 
+// NewBoundedList creates a new List that holds at most maxLen elements and initializes it with the given elements.
+// Once the list is full, PushLeft evicts values from the end of the list and PushRight evicts values from the
+// beginning, like Python's deque with maxlen. Insertions elsewhere evict from the end away from the inserted values,
+// so a successful insertion never evicts the value it just added. A maxLen less than 1 means the list is unbounded.
+// Time complexity: O(n), where n is the number of elements.
+func NewBoundedList[M any](maxLen int, elements ...M) *List[M] {
+	l := &List[M]{maxLen: max(maxLen, 0)}
+	l.PushRight(elements...)
+	return l
+}
+
+// MaxLen returns the maximum length of the list, or 0 if the list is unbounded.
+// Time complexity: O(1).
+func (l *List[M]) MaxLen() int {
+	return l.maxLen
+}
+
+// OnEvict registers a callback that receives each value evicted from a bounded list. A nil callback removes it.
+// Time complexity: O(1).
+func (l *List[M]) OnEvict(callback func(M)) {
+	l.onEvict = callback
+}
+
 // Push adds values to the list.
 // Time complexity: O(n), where n is the number of values.
 func (l *List[M]) Push(values ...M) {
@@ -38,113 +96,628 @@ func (l *List[M]) Pop() M {
 	return l.PopRight()
 }
 
+// TryPop removes and returns the last value from the list. It returns false if the list is empty.
+// Time complexity: O(1).
+func (l *List[M]) TryPop() (M, bool) {
+	return l.TryPopRight()
+}
+
+// PopErr removes and returns the last value from the list. It returns ErrEmpty if the list is empty.
+// Time complexity: O(1).
+func (l *List[M]) PopErr() (M, error) {
+	return l.PopRightErr()
+}
+
 // PushRight adds values to the end of the list.
 // Time complexity: O(n), where n is the number of values.
 func (l *List[M]) PushRight(values ...M) {
 	for _, v := range values {
-		l.length++
-		node := &Node[M]{value: v}
-		if l.head == nil {
-			l.head = node
-			l.tail = node
-		} else {
-			l.tail.next = node
-			node.prev = l.tail
-			l.tail = node
-		}
+		l.insert(&Node[M]{value: v}, l.tail, nil)
+		l.trim(true)
 	}
+	l.check()
 }
 
 // PopRight removes and returns the last value from the list.
 // Time complexity: O(1).
 func (l *List[M]) PopRight() M {
-	l.length--
+	value, _ := l.TryPopRight()
+	return value
+}
+
+// TryPopRight removes and returns the last value from the list. It returns false if the list is empty.
+// Time complexity: O(1).
+func (l *List[M]) TryPopRight() (M, bool) {
 	if l.tail == nil {
 		var m M
-		return m
+		return m, false
 	}
 	node := l.tail
-	l.tail = node.prev
-	if l.tail != nil {
-		l.tail.next = nil
-	} else {
-		l.head = nil
+	l.unlink(node)
+	l.check()
+	return node.value, true
+}
+
+// PopRightErr removes and returns the last value from the list. It returns ErrEmpty if the list is empty.
+// Time complexity: O(1).
+func (l *List[M]) PopRightErr() (M, error) {
+	value, ok := l.TryPopRight()
+	if !ok {
+		return value, ErrEmpty
 	}
-	return node.value
+	return value, nil
 }
 
-// Extend appends other lists to the current list.
+// Extend moves the elements of other lists to the end of the current list, leaving the other lists empty.
+// Extending a list with itself appends a copy of its elements.
 // Time complexity: O(m), where m is the total number of elements in all the lists.
 func (l *List[M]) Extend(lists ...*List[M]) {
 	for _, other := range lists {
-		if other.length == 0 {
-			return
-		}
-		if l.head == nil {
-			l.head = other.head
-			l.tail = other.tail
-		} else {
-			l.tail.next = other.head
-			other.head.prev = l.tail
-			l.tail = other.tail
+		if other == l {
+			other = l.Copy()
 		}
-		l.length += other.length
+		l.splice(l.tail, nil, other, true)
+		other.check()
 	}
+	l.check()
 }
 
 // PopLeft removes and returns the first value from the list.
 // Time complexity: O(1).
 func (l *List[M]) PopLeft() M {
-	l.length--
+	value, _ := l.TryPopLeft()
+	return value
+}
+
+// TryPopLeft removes and returns the first value from the list. It returns false if the list is empty.
+// Time complexity: O(1).
+func (l *List[M]) TryPopLeft() (M, bool) {
 	if l.head == nil {
 		var m M
-		return m
+		return m, false
 	}
 	node := l.head
-	l.head = node.next
-	if l.head != nil {
-		l.head.prev = nil
-	} else {
-		l.tail = nil
+	l.unlink(node)
+	l.check()
+	return node.value, true
+}
+
+// PopLeftErr removes and returns the first value from the list. It returns ErrEmpty if the list is empty.
+// Time complexity: O(1).
+func (l *List[M]) PopLeftErr() (M, error) {
+	value, ok := l.TryPopLeft()
+	if !ok {
+		return value, ErrEmpty
 	}
-	return node.value
+	return value, nil
 }
 
 // PushLeft adds values to the beginning of the list.
 // Time complexity: O(n), where n is the number of values.
 func (l *List[M]) PushLeft(values ...M) {
 	for _, v := range values {
-		l.length++
-		node := &Node[M]{value: v}
-		if l.head == nil {
-			l.head = node
-			l.tail = node
-		} else {
-			l.head.prev = node
-			node.next = l.head
-			l.head = node
+		l.insert(&Node[M]{value: v}, nil, l.head)
+		l.trim(false)
+	}
+	l.check()
+}
+
+// Front returns the first node of the list, or nil if the list is empty.
+// Time complexity: O(1).
+func (l *List[M]) Front() *Node[M] {
+	return l.head
+}
+
+// Back returns the last node of the list, or nil if the list is empty.
+// Time complexity: O(1).
+func (l *List[M]) Back() *Node[M] {
+	return l.tail
+}
+
+// InsertBefore inserts a value immediately before mark and returns its node. A full bounded list evicts its last value.
+// If mark is not an element of the list, the list is not modified and nil is returned.
+// Time complexity: O(1), or O(n) with observers registered, where n is the number of elements in the list.
+func (l *List[M]) InsertBefore(value M, mark *Node[M]) *Node[M] {
+	if mark == nil || mark.list != l {
+		return nil
+	}
+	node := l.insert(&Node[M]{value: value}, mark.prev, mark)
+	l.trim(false)
+	l.check()
+	return node
+}
+
+// InsertAfter inserts a value immediately after mark and returns its node. A full bounded list evicts its first value.
+// If mark is not an element of the list, the list is not modified and nil is returned.
+// Time complexity: O(1), or O(n) with observers registered, where n is the number of elements in the list.
+func (l *List[M]) InsertAfter(value M, mark *Node[M]) *Node[M] {
+	if mark == nil || mark.list != l {
+		return nil
+	}
+	node := l.insert(&Node[M]{value: value}, mark, mark.next)
+	l.trim(true)
+	l.check()
+	return node
+}
+
+// RemoveNode removes a node from the list and returns its value.
+// If the node is not an element of the list, the list is not modified.
+// Time complexity: O(1), or O(n) with observers registered, where n is the number of elements in the list.
+func (l *List[M]) RemoveNode(node *Node[M]) M {
+	if node.list == l {
+		l.unlink(node)
+		l.check()
+	}
+	return node.value
+}
+
+// MoveToFront moves a node to the beginning of the list.
+// If the node is not an element of the list, the list is not modified.
+// Time complexity: O(1), or O(n) with observers registered, where n is the number of elements in the list.
+func (l *List[M]) MoveToFront(node *Node[M]) {
+	if node.list != l || l.head == node {
+		return
+	}
+	l.unlink(node)
+	l.insert(node, nil, l.head)
+	l.check()
+}
+
+// MoveToBack moves a node to the end of the list.
+// If the node is not an element of the list, the list is not modified.
+// Time complexity: O(1), or O(n) with observers registered, where n is the number of elements in the list.
+func (l *List[M]) MoveToBack(node *Node[M]) {
+	if node.list != l || l.tail == node {
+		return
+	}
+	l.unlink(node)
+	l.insert(node, l.tail, nil)
+	l.check()
+}
+
+// MoveAfter moves a node to the position immediately after mark.
+// If either node is not an element of the list, or they are the same node, the list is not modified.
+// Time complexity: O(1), or O(n) with observers registered, where n is the number of elements in the list.
+func (l *List[M]) MoveAfter(node, mark *Node[M]) {
+	if node.list != l || mark.list != l || node == mark {
+		return
+	}
+	l.unlink(node)
+	l.insert(node, mark, mark.next)
+	l.check()
+}
+
+// At returns the value at index i. Negative indices count back from the end of the list.
+// It returns ErrOutOfRange if the index is outside the list.
+// Time complexity: O(min(i, n-i)), where n is the number of elements in the list.
+func (l *List[M]) At(i int) (M, error) {
+	node, err := l.nodeAt(i)
+	if err != nil {
+		var m M
+		return m, err
+	}
+	return node.value, nil
+}
+
+// SetAt replaces the value at index i. Negative indices count back from the end of the list.
+// It returns ErrOutOfRange if the index is outside the list.
+// Time complexity: O(min(i, n-i)), or O(i) with observers registered, where n is the number of elements in the list.
+func (l *List[M]) SetAt(i int, value M) error {
+	node, err := l.nodeAt(i)
+	if err != nil {
+		return err
+	}
+	if l.tx != nil {
+		old := node.value
+		l.tx.recordChecked(func() error {
+			if node.list != l {
+				return ErrTxConflict
+			}
+			l.replace(node, old)
+			return nil
+		})
+	}
+	l.replace(node, value)
+	return nil
+}
+
+// InsertAt inserts values before index i, so that the first value ends up at index i.
+// An index equal to the length of the list appends the values. Negative indices count back from the end of the list.
+// A full bounded list evicts values from whichever end has more values on the far side of the insertion point.
+// It returns ErrOutOfRange if the index is outside the list.
+// Time complexity: O(min(i, n-i) + m), or O(m(i + m)) with observers registered, where n is the number of elements in
+// the list and m is the number of values.
+func (l *List[M]) InsertAt(i int, values ...M) error {
+	i, err := resolveIndex(i, l.Len(), true)
+	if err != nil {
+		return err
+	}
+	var mark *Node[M]
+	if i < l.Len() {
+		mark = l.walk(i)
+	}
+	for _, v := range values {
+		prev := l.tail
+		if mark != nil {
+			prev = mark.prev
+		}
+		l.insert(&Node[M]{value: v}, prev, mark)
+	}
+	l.trim(i >= l.Len()-i-len(values))
+	l.check()
+	return nil
+}
+
+// RemoveAt removes and returns the value at index i. Negative indices count back from the end of the list.
+// It returns ErrOutOfRange if the index is outside the list.
+// Time complexity: O(min(i, n-i)), or O(i) with observers registered, where n is the number of elements in the list.
+func (l *List[M]) RemoveAt(i int) (M, error) {
+	node, err := l.nodeAt(i)
+	if err != nil {
+		var m M
+		return m, err
+	}
+	l.unlink(node)
+	l.check()
+	return node.value, nil
+}
+
+// Rotate rotates the list k steps to the right, or -k steps to the left if k is negative.
+// Rotating by one step to the right moves the last element to the beginning.
+// Time complexity: O(min(k, n-k)), where n is the number of elements in the list.
+func (l *List[M]) Rotate(k int) {
+	n := l.Len()
+	if n < 2 {
+		return
+	}
+	k = ((k % n) + n) % n
+	if k == 0 {
+		return
+	}
+	head := l.walk(n - k)
+	l.tail.next = l.head
+	l.head.prev = l.tail
+	l.head = head
+	l.tail = head.prev
+	l.head.prev = nil
+	l.tail.next = nil
+	if l.tx != nil {
+		l.tx.record(func() { l.Rotate(-k) })
+	}
+	l.observers.emit(Event[M]{Kind: EventReordered, Index: -1})
+	l.check()
+}
+
+// SplitAt cuts the list before index i. The list keeps the elements before i and the elements from i onwards
+// are moved to the returned list. Negative indices count back from the end of the list.
+// It returns ErrOutOfRange if the index is outside the list.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (l *List[M]) SplitAt(i int) (*List[M], error) {
+	i, err := resolveIndex(i, l.Len(), true)
+	if err != nil {
+		return nil, err
+	}
+	result := NewList[M]()
+	if i == l.Len() {
+		return result, nil
+	}
+	first := l.walk(i)
+	if l.tx != nil {
+		nodes := nodesFrom(first)
+		l.tx.recordChecked(func() error {
+			if !isRun(nodes, result) {
+				return ErrTxConflict
+			}
+			moveRun(result, l, nodes[0], nodes[len(nodes)-1], int64(len(nodes)), l.tail, nil)
+			return nil
+		})
+	}
+	moveRun(l, result, first, l.tail, l.length-int64(i), nil, nil)
+	l.check()
+	result.check()
+	return result, nil
+}
+
+// Splice moves the elements of another list into the list before index i, leaving the other list empty.
+// An index equal to the length of the list appends the elements. Negative indices count back from the end of the list.
+// A full bounded list evicts values from whichever end has more values on the far side of the insertion point.
+// It returns ErrOutOfRange if the index is outside the list and ErrSameList if other is the list itself.
+// Time complexity: O(min(i, n-i) + m), or O(i + m) with observers registered, where n is the number of elements in the
+// list and m is the number of elements in other.
+func (l *List[M]) Splice(i int, other *List[M]) error {
+	if other == l {
+		return ErrSameList
+	}
+	i, err := resolveIndex(i, l.Len(), true)
+	if err != nil {
+		return err
+	}
+	left := i >= l.Len()-i
+	if i == l.Len() {
+		l.splice(l.tail, nil, other, left)
+	} else {
+		mark := l.walk(i)
+		l.splice(mark.prev, mark, other, left)
+	}
+	l.check()
+	other.check()
+	return nil
+}
+
+// splice moves every node of other between prev and next, either of which may be nil at the ends of the list.
+// The move is recorded by the open transactions of both lists, and rolling back either one returns the nodes to other.
+// A full bounded list then evicts from the beginning if left is true and from the end otherwise.
+// Time complexity: O(m), where m is the number of elements in other.
+func (l *List[M]) splice(prev, next *Node[M], other *List[M], left bool) {
+	if other.length == 0 {
+		return
+	}
+	if l.tx != nil || other.tx != nil {
+		nodes := other.nodes()
+		undone := false
+		undo := func() error {
+			if undone {
+				return nil
+			}
+			if !isRun(nodes, l) {
+				return ErrTxConflict
+			}
+			moveRun(l, other, nodes[0], nodes[len(nodes)-1], int64(len(nodes)), nil, other.head)
+			undone = true
+			return nil
+		}
+		if l.tx != nil {
+			l.tx.recordChecked(undo)
+		}
+		if other.tx != nil {
+			other.tx.recordChecked(undo)
+		}
+	}
+	moveRun(other, l, other.head, other.tail, other.length, prev, next)
+	l.trim(left)
+}
+
+// moveRun moves the run of count nodes from first to last out of the list from and links it into the list to
+// between prev and next, either of which may be nil at the ends of the list.
+// Time complexity: O(count).
+func moveRun[M any](from, to *List[M], first, last *Node[M], count int64, prev, next *Node[M]) {
+	var fromIndex, toIndex int
+	if len(from.observers) > 0 {
+		fromIndex = from.indexOfNode(first)
+	}
+	if len(to.observers) > 0 && prev != nil {
+		toIndex = to.indexOfNode(prev) + 1
+	}
+	if first.prev != nil {
+		first.prev.next = last.next
+	} else {
+		from.head = last.next
+	}
+	if last.next != nil {
+		last.next.prev = first.prev
+	} else {
+		from.tail = first.prev
+	}
+	from.length -= count
+	for n := first; ; n = n.next {
+		n.list = to
+		if n == last {
+			break
+		}
+	}
+	first.prev = prev
+	if prev != nil {
+		prev.next = first
+	} else {
+		to.head = first
+	}
+	last.next = next
+	if next != nil {
+		next.prev = last
+	} else {
+		to.tail = last
+	}
+	to.length += count
+	if len(from.observers) > 0 || len(to.observers) > 0 {
+		for n := first; ; n = n.next {
+			from.observers.emit(Event[M]{Kind: EventRemoved, Value: n.value, Index: fromIndex})
+			to.observers.emit(Event[M]{Kind: EventInserted, Value: n.value, Index: toIndex})
+			toIndex++
+			if n == last {
+				break
+			}
+		}
+	}
+}
+
+// trim evicts values from the beginning or end of a bounded list until it is within its maximum length.
+// Time complexity: O(k), where k is the number of evicted values.
+func (l *List[M]) trim(left bool) {
+	for l.maxLen > 0 && l.Len() > l.maxLen {
+		node := l.tail
+		if left {
+			node = l.head
+		}
+		l.unlink(node)
+		if l.onEvict != nil {
+			l.onEvict(node.value)
 		}
 	}
 }
 
+// nodeAt returns the node at index i, resolving negative indices from the end of the list.
+// Time complexity: O(min(i, n-i)), where n is the number of elements in the list.
+func (l *List[M]) nodeAt(i int) (*Node[M], error) {
+	i, err := resolveIndex(i, l.Len(), false)
+	if err != nil {
+		return nil, err
+	}
+	return l.walk(i), nil
+}
+
+// walk returns the node at index i, starting from whichever end of the list is closer.
+// The index must be within the list.
+// Time complexity: O(min(i, n-i)), where n is the number of elements in the list.
+func (l *List[M]) walk(i int) *Node[M] {
+	if i < l.Len()/2 {
+		n := l.head
+		for ; i > 0; i-- {
+			n = n.next
+		}
+		return n
+	}
+	n := l.tail
+	for j := l.Len() - 1; j > i; j-- {
+		n = n.prev
+	}
+	return n
+}
+
+// insert links a node between prev and next, either of which may be nil at the ends of the list.
+// Time complexity: O(1).
+func (l *List[M]) insert(node, prev, next *Node[M]) *Node[M] {
+	node.list = l
+	node.prev = prev
+	node.next = next
+	if prev != nil {
+		prev.next = node
+	} else {
+		l.head = node
+	}
+	if next != nil {
+		next.prev = node
+	} else {
+		l.tail = node
+	}
+	l.length++
+	if l.tx != nil {
+		l.tx.recordChecked(func() error {
+			if node.list != l {
+				return ErrTxConflict
+			}
+			l.unlink(node)
+			return nil
+		})
+	}
+	if len(l.observers) > 0 {
+		l.observers.emit(Event[M]{Kind: EventInserted, Value: node.value, Index: l.indexOfNode(node)})
+	}
+	return node
+}
+
+// unlink detaches a node from the list.
+// Time complexity: O(1).
+func (l *List[M]) unlink(node *Node[M]) {
+	if l.tx != nil {
+		prev, next := node.prev, node.next
+		l.tx.recordChecked(func() error {
+			if node.list != nil || !l.adjacent(prev, next) {
+				return ErrTxConflict
+			}
+			l.insert(node, prev, next)
+			return nil
+		})
+	}
+	index := -1
+	if len(l.observers) > 0 {
+		index = l.indexOfNode(node)
+	}
+	if node.prev != nil {
+		node.prev.next = node.next
+	} else {
+		l.head = node.next
+	}
+	if node.next != nil {
+		node.next.prev = node.prev
+	} else {
+		l.tail = node.prev
+	}
+	node.next = nil
+	node.prev = nil
+	node.list = nil
+	l.length--
+	if len(l.observers) > 0 {
+		l.observers.emit(Event[M]{Kind: EventRemoved, Value: node.value, Index: index})
+	}
+}
+
+// replace sets the value of a node, reporting the removal of the old value and the insertion of the new one.
+// Time complexity: O(1), or O(i) with observers registered, where i is the node's index.
+func (l *List[M]) replace(node *Node[M], value M) {
+	old := node.value
+	node.value = value
+	if len(l.observers) > 0 {
+		i := l.indexOfNode(node)
+		l.observers.emit(Event[M]{Kind: EventRemoved, Value: old, Index: i})
+		l.observers.emit(Event[M]{Kind: EventInserted, Value: value, Index: i})
+	}
+}
+
+// indexOfNode returns the index of a node in the list, walking from the end of the list when the node is the tail.
+// Time complexity: O(i), where i is the node's index.
+func (l *List[M]) indexOfNode(node *Node[M]) int {
+	if node == l.tail {
+		return l.Len() - 1
+	}
+	i := 0
+	for n := l.head; n != node; n = n.next {
+		i++
+	}
+	return i
+}
+
 // PeekLeft returns the first value in the list without removing it.
 // Time complexity: O(1).
 func (l *List[M]) PeekLeft() M {
+	value, _ := l.TryPeekLeft()
+	return value
+}
+
+// TryPeekLeft returns the first value in the list without removing it. It returns false if the list is empty.
+// Time complexity: O(1).
+func (l *List[M]) TryPeekLeft() (M, bool) {
 	if l.head == nil {
 		var m M
-		return m
+		return m, false
 	}
-	return l.head.value
+	return l.head.value, true
+}
+
+// PeekLeftErr returns the first value in the list without removing it. It returns ErrEmpty if the list is empty.
+// Time complexity: O(1).
+func (l *List[M]) PeekLeftErr() (M, error) {
+	value, ok := l.TryPeekLeft()
+	if !ok {
+		return value, ErrEmpty
+	}
+	return value, nil
 }
 
 // PeekRight returns the last value in the list without removing it.
 // Time complexity: O(1).
 func (l *List[M]) PeekRight() M {
+	value, _ := l.TryPeekRight()
+	return value
+}
+
+// TryPeekRight returns the last value in the list without removing it. It returns false if the list is empty.
+// Time complexity: O(1).
+func (l *List[M]) TryPeekRight() (M, bool) {
 	if l.tail == nil {
 		var m M
-		return m
+		return m, false
 	}
-	return l.tail.value
+	return l.tail.value, true
+}
+
+// PeekRightErr returns the last value in the list without removing it. It returns ErrEmpty if the list is empty.
+// Time complexity: O(1).
+func (l *List[M]) PeekRightErr() (M, error) {
+	value, ok := l.TryPeekRight()
+	if !ok {
+		return value, ErrEmpty
+	}
+	return value, nil
 }
 
 // Reverse reverses the order of the list.
@@ -154,12 +727,17 @@ func (l *List[M]) Reverse() {
 		n.next, n.prev = n.prev, n.next
 	}
 	l.head, l.tail = l.tail, l.head
+	if l.tx != nil {
+		l.tx.record(l.Reverse)
+	}
+	l.observers.emit(Event[M]{Kind: EventReordered, Index: -1})
+	l.check()
 }
 
 // String returns a string representation of the list.
 // Time complexity: O(n), where n is the number of elements in the list.
 func (l *List[M]) String() string {
-	return fmt.Sprintf("%v", l.Elements())
+	return fmt.Sprintf("%v", l)
 }
 
 // Each applies a callback function to each value in the list.
@@ -175,15 +753,68 @@ func (l *List[M]) Each(callback func(int, M)) {
 // Find returns the first value in the list that satisfies the callback function.
 // Time complexity: O(n), where n is the number of elements in the list.
 func (l *List[M]) Find(callback func(int, M) bool) M {
+	value, _ := l.FindOk(callback)
+	return value
+}
+
+// FindOk returns the first value in the list that satisfies the callback function. It returns false if no value does.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (l *List[M]) FindOk(callback func(int, M) bool) (M, bool) {
 	i := 0
 	for n := l.head; n != nil; n = n.next {
 		if callback(i, n.value) {
-			return n.value
+			return n.value, true
 		}
 		i++
 	}
 	var m M
-	return m
+	return m, false
+}
+
+// Iter returns an iterator over the index and value of each element in the list, from left to right.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (l *List[M]) Iter() iter.Seq2[int, M] {
+	return func(yield func(int, M) bool) {
+		i := 0
+		for n := l.head; n != nil; n = n.next {
+			if !yield(i, n.value) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Backward returns an iterator over the index and value of each element in the list, from right to left.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (l *List[M]) Backward() iter.Seq2[int, M] {
+	return func(yield func(int, M) bool) {
+		i := l.Len() - 1
+		for n := l.tail; n != nil; n = n.prev {
+			if !yield(i, n.value) {
+				return
+			}
+			i--
+		}
+	}
+}
+
+// Values returns an iterator over the values in the list, from left to right.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (l *List[M]) Values() iter.Seq[M] {
+	return func(yield func(M) bool) {
+		for n := l.head; n != nil; n = n.next {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Stream returns a lazy stream over the values in the list, from left to right.
+// Time complexity: O(1).
+func (l *List[M]) Stream() *Stream[M] {
+	return NewStream(l.Values())
 }
 
 // Elements returns a slice containing all the values in the list.
@@ -210,6 +841,65 @@ func (l *List[M]) Filter(callback func(int, M) bool) *List[M] {
 	return result
 }
 
+// RemoveFunc removes every value that satisfies the callback function and returns how many were removed.
+// The callback receives each value's index in the list before any removals.
+// Time complexity: O(n), or O(n²) with observers registered because each removal walks the list to report its index,
+// where n is the number of elements in the list.
+func (l *List[M]) RemoveFunc(callback func(int, M) bool) int {
+	removed := 0
+	i := 0
+	for n := l.head; n != nil; i++ {
+		next := n.next
+		if callback(i, n.value) {
+			l.unlink(n)
+			removed++
+		}
+		n = next
+	}
+	l.check()
+	return removed
+}
+
+// Retain removes every value that does not satisfy the callback function and returns how many were removed.
+// The callback receives each value's index in the list before any removals.
+// Time complexity: O(n), or O(n²) with observers registered because each removal walks the list to report its index,
+// where n is the number of elements in the list.
+func (l *List[M]) Retain(callback func(int, M) bool) int {
+	return l.RemoveFunc(func(i int, value M) bool {
+		return !callback(i, value)
+	})
+}
+
+// Clear removes all elements from the list. Node handles to the removed elements are detached.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (l *List[M]) Clear() {
+	if l.tx != nil && l.length > 0 {
+		nodes := l.nodes()
+		l.tx.recordChecked(func() error {
+			if l.length != 0 || slices.ContainsFunc(nodes, func(n *Node[M]) bool { return n.list != nil }) {
+				return ErrTxConflict
+			}
+			l.relink(nodes)
+			for i, n := range nodes {
+				l.observers.emit(Event[M]{Kind: EventInserted, Value: n.value, Index: i})
+			}
+			return nil
+		})
+	}
+	for n := l.head; n != nil; {
+		next := n.next
+		n.next = nil
+		n.prev = nil
+		n.list = nil
+		n = next
+	}
+	l.head = nil
+	l.tail = nil
+	l.length = 0
+	l.observers.emit(Event[M]{Kind: EventCleared, Index: -1})
+	l.check()
+}
+
 // All returns true if all values in the list satisfy the callback function, false otherwise.
 // Time complexity: O(n), where n is the number of elements in the list.
 func (l *List[M]) All(callback func(int, M) bool) bool {
@@ -249,26 +939,38 @@ func IndexOf[M comparable](list *List[M], value M) int {
 	return -1
 }
 
-// Remove removes all occurrences of the value from the list.
+// Remove removes all occurrences of the value from the list and returns how many were removed.
+// Time complexity: O(n), where n is the number of elements in the list.
+func Remove[M comparable](list *List[M], value M) int {
+	return list.RemoveFunc(func(_ int, v M) bool {
+		return v == value
+	})
+}
+
+// RemoveFirst removes the first occurrence of the value from the list. It returns false if the value is not present.
 // Time complexity: O(n), where n is the number of elements in the list.
-func Remove[M comparable](list *List[M], value M) {
+func RemoveFirst[M comparable](list *List[M], value M) bool {
 	for n := list.head; n != nil; n = n.next {
 		if n.value == value {
-			if n == list.head {
-				list.head = n.next
-				if n.next != nil {
-					n.next.prev = nil
-				}
-			} else if n == list.tail {
-				list.tail = n.prev
-				n.prev.next = nil
-			} else {
-				n.prev.next = n.next
-				n.next.prev = n.prev
-			}
-			list.length--
+			list.unlink(n)
+			list.check()
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveLast removes the last occurrence of the value from the list. It returns false if the value is not present.
+// Time complexity: O(n), where n is the number of elements in the list.
+func RemoveLast[M comparable](list *List[M], value M) bool {
+	for n := list.tail; n != nil; n = n.prev {
+		if n.value == value {
+			list.unlink(n)
+			list.check()
+			return true
 		}
 	}
+	return false
 }
 
 // Slice returns a new list containing the elements from the start index to the stop index.
@@ -288,40 +990,173 @@ func (l *List[M]) Slice(start, stop int) *List[M] {
 	})
 }
 
-// Sort returns a new sorted list using the provided less function.
+// Sort returns a new list with the values of the list sorted using the provided less function.
+// The sort is stable.
 // Time complexity: O(n log n), where n is the number of elements in the list.
 func (l *List[M]) Sort(less func(M, M) bool) *List[M] {
+	result := l.Copy()
+	result.SortInPlace(less)
+	return result
+}
+
+// SortInPlace sorts the list using the provided less function by relinking its nodes.
+// The sort is stable and node handles remain valid.
+// Time complexity: O(n log n), where n is the number of elements in the list.
+func (l *List[M]) SortInPlace(less func(M, M) bool) {
 	if l.length < 2 {
-		return l
+		return
+	}
+	if l.tx != nil {
+		nodes := l.nodes()
+		l.tx.recordChecked(func() error {
+			if l.length != int64(len(nodes)) || slices.ContainsFunc(nodes, func(n *Node[M]) bool { return n.list != l }) {
+				return ErrTxConflict
+			}
+			l.relink(nodes)
+			l.observers.emit(Event[M]{Kind: EventReordered, Index: -1})
+			return nil
+		})
+	}
+	// Bottom-up merge sort over the next pointers, merging runs of doubling width.
+	for width := 1; ; width *= 2 {
+		var head, tail *Node[M]
+		merges := 0
+		for n := l.head; n != nil; {
+			merges++
+			left := n
+			right := cut(left, width)
+			n = cut(right, width)
+			first, last := merge(left, right, less)
+			if head == nil {
+				head = first
+			} else {
+				tail.next = first
+			}
+			tail = last
+		}
+		l.head = head
+		if merges <= 1 {
+			break
+		}
+	}
+	var prev *Node[M]
+	for n := l.head; n != nil; n = n.next {
+		n.prev = prev
+		prev = n
 	}
-	left, pivot, right := l.partition(less)
-	return stitch(left.Sort(less), pivot, right.Sort(less))
+	l.tail = prev
+	l.observers.emit(Event[M]{Kind: EventReordered, Index: -1})
+	l.check()
 }
 
-// partition partitions the list into three parts: left, pivot, and right.
+// nodes returns a slice containing the nodes of the list, in order.
 // Time complexity: O(n), where n is the number of elements in the list.
-func (l *List[M]) partition(less func(M, M) bool) (*List[M], M, *List[M]) {
-	pivot := l.head.value
-	left := NewList[M]()
-	right := NewList[M]()
-	for n := l.head.next; n != nil; n = n.next {
-		if less(n.value, pivot) {
-			left.PushRight(n.value)
+func (l *List[M]) nodes() []*Node[M] {
+	result := make([]*Node[M], 0, l.length)
+	for n := l.head; n != nil; n = n.next {
+		result = append(result, n)
+	}
+	return result
+}
+
+// nodesFrom returns the node and the nodes after it in its list.
+// Time complexity: O(m), where m is the number of nodes returned.
+func nodesFrom[M any](first *Node[M]) []*Node[M] {
+	var result []*Node[M]
+	for n := first; n != nil; n = n.next {
+		result = append(result, n)
+	}
+	return result
+}
+
+// isRun reports whether the nodes are still linked in order, one after another, in the list.
+// Time complexity: O(m), where m is the number of nodes.
+func isRun[M any](nodes []*Node[M], l *List[M]) bool {
+	for i, n := range nodes {
+		if n.list != l || (i > 0 && n.prev != nodes[i-1]) {
+			return false
+		}
+	}
+	return len(nodes) > 0
+}
+
+// adjacent reports whether prev and next are neighbours in the list, where a nil prev or next stands for
+// the start or end of the list.
+// Time complexity: O(1).
+func (l *List[M]) adjacent(prev, next *Node[M]) bool {
+	if prev == nil {
+		if l.head != next {
+			return false
+		}
+	} else if prev.list != l || prev.next != next {
+		return false
+	}
+	if next == nil {
+		return l.tail == prev
+	}
+	return next.list == l && next.prev == prev
+}
+
+// relink replaces the contents of the list with the given nodes, linked in order.
+// Time complexity: O(m), where m is the number of nodes.
+func (l *List[M]) relink(nodes []*Node[M]) {
+	var prev *Node[M]
+	l.head = nil
+	for _, n := range nodes {
+		n.list = l
+		n.prev = prev
+		n.next = nil
+		if prev != nil {
+			prev.next = n
 		} else {
-			right.PushRight(n.value)
+			l.head = n
 		}
+		prev = n
 	}
-	return left, pivot, right
+	l.tail = prev
+	l.length = int64(len(nodes))
 }
 
-// stitch combines the left, pivot, and right lists into a single list.
-// Time complexity: O(n), where n is the total number of elements in the left, pivot, and right lists.
-func stitch[M any](left *List[M], pivot M, right *List[M]) *List[M] {
-	if left.length == 0 {
-		return Join(NewList[M](pivot), right)
+// cut detaches the run of at most width nodes starting at node and returns the node following it.
+// Time complexity: O(width).
+func cut[M any](node *Node[M], width int) *Node[M] {
+	for i := 1; node != nil && i < width; i++ {
+		node = node.next
+	}
+	if node == nil {
+		return nil
 	}
-	left.PushRight(pivot)
-	return Join(left, right)
+	next := node.next
+	node.next = nil
+	return next
+}
+
+// merge merges two sorted runs linked by their next pointers and returns the first and last nodes of the result.
+// Nodes from left are taken first on ties, which keeps the sort stable.
+// Time complexity: O(n), where n is the total number of nodes in both runs.
+func merge[M any](left, right *Node[M], less func(M, M) bool) (*Node[M], *Node[M]) {
+	var head, tail *Node[M]
+	for left != nil || right != nil {
+		var node *Node[M]
+		if right == nil || (left != nil && !less(right.value, left.value)) {
+			node, left = left, left.next
+		} else {
+			node, right = right, right.next
+		}
+		if head == nil {
+			head = node
+		} else {
+			tail.next = node
+		}
+		tail = node
+	}
+	return head, tail
+}
+
+// SortOrdered returns a new list with the ordered values of the list sorted from smallest to largest.
+// Time complexity: O(n log n), where n is the number of elements in the list.
+func SortOrdered[T cmp.Ordered](l *List[T]) *List[T] {
+	return l.Sort(Less[T])
 }
 
 // IsSorted returns true if the list is sorted in non-decreasing order according to the provided less function, false otherwise.
@@ -335,16 +1170,22 @@ func (l *List[M]) IsSorted(less func(M, M) bool) bool {
 	return true
 }
 
-// Copy returns a new list with the same values as the original list.
+// Copy returns a new list with the same values and maximum length as the original list.
 // Time complexity: O(n), where n is the number of elements in the list.
 func (l *List[M]) Copy() *List[M] {
-	result := &List[M]{}
+	result := &List[M]{maxLen: l.maxLen}
 	for n := l.head; n != nil; n = n.next {
 		result.PushRight(n.value)
 	}
 	return result
 }
 
+// ToPList returns a new persistent list with the values of the list.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (l *List[M]) ToPList() PList[M] {
+	return NewPList(l.Elements()...)
+}
+
 // Len returns the length of the list.
 // Time complexity: O(1).
 func (l *List[M]) Len() int {
@@ -377,6 +1218,279 @@ func Reduce[M any, N any](l *List[M], callback func(N, M) N, initial N) N {
 	return acc
 }
 
+// ParallelMap applies a callback function to each value in the list on a pool of goroutines and returns a new list
+// with the results in the original order. It stops early and returns the context's error if ctx is cancelled.
+// Time complexity: O(n), where n is the number of elements in the list. The callback calls are spread over p goroutines,
+// where p is GOMAXPROCS, but copying the values and building the result run serially.
+func ParallelMap[M any, N any](ctx context.Context, l *List[M], callback func(M) N) (*List[N], error) {
+	values := l.Elements()
+	results := make([]N, len(values))
+	err := parallelChunks(ctx, len(values), func(_, start, end int) {
+		for i := start; i < end && ctx.Err() == nil; i++ {
+			results[i] = callback(values[i])
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewList(results...), nil
+}
+
+// ParallelFilter evaluates a callback function for each value in the list on a pool of goroutines and returns a new
+// list with the values that satisfy it, in the original order. It stops early and returns the context's error if ctx
+// is cancelled.
+// Time complexity: O(n), where n is the number of elements in the list. The callback calls are spread over p goroutines,
+// where p is GOMAXPROCS, but copying the values and building the result run serially.
+func ParallelFilter[M any](ctx context.Context, l *List[M], callback func(M) bool) (*List[M], error) {
+	values := l.Elements()
+	keep := make([]bool, len(values))
+	err := parallelChunks(ctx, len(values), func(_, start, end int) {
+		for i := start; i < end && ctx.Err() == nil; i++ {
+			keep[i] = callback(values[i])
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := &List[M]{}
+	for i, v := range values {
+		if keep[i] {
+			result.PushRight(v)
+		}
+	}
+	return result, nil
+}
+
+// ParallelReduce reduces contiguous chunks of the list on a pool of goroutines, starting each chunk from initial,
+// and merges the partial results in order with the combine function. The combine function must be associative and
+// initial must be its identity. It stops early and returns the context's error if ctx is cancelled.
+// Time complexity: O(n + p), where n is the number of elements in the list and p is GOMAXPROCS. The callback calls are
+// spread over p goroutines, but copying the values runs serially.
+func ParallelReduce[M any, N any](ctx context.Context, l *List[M], callback func(N, M) N, combine func(N, N) N, initial N) (N, error) {
+	values := l.Elements()
+	partials := make([]N, chunkCount(len(values)))
+	err := parallelChunks(ctx, len(values), func(chunk, start, end int) {
+		acc := initial
+		for i := start; i < end && ctx.Err() == nil; i++ {
+			acc = callback(acc, values[i])
+		}
+		partials[chunk] = acc
+	})
+	if err != nil {
+		var n N
+		return n, err
+	}
+	acc := initial
+	for _, partial := range partials {
+		acc = combine(acc, partial)
+	}
+	return acc, nil
+}
+
+// chunkCount returns the number of chunks parallelChunks splits n items into.
+// Time complexity: O(1).
+func chunkCount(n int) int {
+	return min(n, 4*runtime.GOMAXPROCS(0))
+}
+
+// parallelChunks splits n items into contiguous chunks and calls work for each chunk on at most GOMAXPROCS goroutines.
+// It returns the context's error if ctx is cancelled before every chunk is processed.
+// Time complexity: O(n/p), where p is GOMAXPROCS, assuming work is linear in the chunk size.
+func parallelChunks(ctx context.Context, n int, work func(chunk, start, end int)) error {
+	chunks := chunkCount(n)
+	if chunks == 0 {
+		return ctx.Err()
+	}
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(chunks, runtime.GOMAXPROCS(0)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range next {
+				work(chunk, chunk*n/chunks, (chunk+1)*n/chunks)
+			}
+		}()
+	}
+	for chunk := 0; chunk < chunks && ctx.Err() == nil; chunk++ {
+		select {
+		case next <- chunk:
+		case <-ctx.Done():
+		}
+	}
+	close(next)
+	wg.Wait()
+	return ctx.Err()
+}
+
+// Scan applies a callback function to each value in the list and returns a new list with each intermediate accumulated value.
+// The initial value is not included in the result.
+// Time complexity: O(n), where n is the number of elements in the list.
+func Scan[M any, N any](l *List[M], callback func(N, M) N, initial N) *List[N] {
+	acc := initial
+	result := &List[N]{}
+	for n := l.head; n != nil; n = n.next {
+		acc = callback(acc, n.value)
+		result.PushRight(acc)
+	}
+	return result
+}
+
+// FlatMap applies a callback function to each value in the list and returns a new list with the concatenated results.
+// Time complexity: O(m), where m is the total number of elements in the results.
+func FlatMap[M any, N any](l *List[M], callback func(M) *List[N]) *List[N] {
+	result := &List[N]{}
+	for n := l.head; n != nil; n = n.next {
+		for m := callback(n.value).head; m != nil; m = m.next {
+			result.PushRight(m.value)
+		}
+	}
+	return result
+}
+
+// Flatten returns a new list with the values of each list in order.
+// Time complexity: O(m), where m is the total number of elements in all the lists.
+func Flatten[M any](lists *List[*List[M]]) *List[M] {
+	return FlatMap(lists, func(l *List[M]) *List[M] {
+		return l
+	})
+}
+
+// Partition returns two new lists: the values that satisfy the callback function and the values that do not.
+// Time complexity: O(n), where n is the number of elements in the list.
+func Partition[M any](l *List[M], callback func(M) bool) (*List[M], *List[M]) {
+	matched := &List[M]{}
+	rest := &List[M]{}
+	for n := l.head; n != nil; n = n.next {
+		if callback(n.value) {
+			matched.PushRight(n.value)
+		} else {
+			rest.PushRight(n.value)
+		}
+	}
+	return matched, rest
+}
+
+// GroupBy returns a map from each key returned by the callback function to a new list of the values with that key.
+// Time complexity: O(n), where n is the number of elements in the list.
+func GroupBy[M any, K comparable](l *List[M], callback func(M) K) map[K]*List[M] {
+	result := make(map[K]*List[M])
+	for n := l.head; n != nil; n = n.next {
+		key := callback(n.value)
+		group, ok := result[key]
+		if !ok {
+			group = &List[M]{}
+			result[key] = group
+		}
+		group.PushRight(n.value)
+	}
+	return result
+}
+
+// Chunk returns a new list of consecutive sublists with size values each. The last sublist may be shorter.
+// It panics if size is less than 1.
+// Time complexity: O(n), where n is the number of elements in the list.
+func Chunk[M any](l *List[M], size int) *List[*List[M]] {
+	if size < 1 {
+		panic("q: chunk size must be positive")
+	}
+	result := &List[*List[M]]{}
+	for n := l.head; n != nil; n = n.next {
+		if result.tail == nil || result.tail.value.Len() == size {
+			result.PushRight(&List[M]{})
+		}
+		result.tail.value.PushRight(n.value)
+	}
+	return result
+}
+
+// Window returns a new list of every sublist of size consecutive values, in order.
+// It returns an empty list if the list has fewer than size values and panics if size is less than 1.
+// Time complexity: O(n*k), where n is the number of elements in the list and k is the window size.
+func Window[M any](l *List[M], size int) *List[*List[M]] {
+	if size < 1 {
+		panic("q: window size must be positive")
+	}
+	result := &List[*List[M]]{}
+	window := &List[M]{}
+	for n := l.head; n != nil; n = n.next {
+		window.PushRight(n.value)
+		if window.Len() > size {
+			window.PopLeft()
+		}
+		if window.Len() == size {
+			result.PushRight(window.Copy())
+		}
+	}
+	return result
+}
+
+// TakeWhile returns a new list with the leading values that satisfy the callback function.
+// Time complexity: O(n), where n is the number of elements in the list.
+func TakeWhile[M any](l *List[M], callback func(M) bool) *List[M] {
+	result := &List[M]{}
+	for n := l.head; n != nil && callback(n.value); n = n.next {
+		result.PushRight(n.value)
+	}
+	return result
+}
+
+// DropWhile returns a new list without the leading values that satisfy the callback function.
+// Time complexity: O(n), where n is the number of elements in the list.
+func DropWhile[M any](l *List[M], callback func(M) bool) *List[M] {
+	n := l.head
+	for n != nil && callback(n.value) {
+		n = n.next
+	}
+	result := &List[M]{}
+	for ; n != nil; n = n.next {
+		result.PushRight(n.value)
+	}
+	return result
+}
+
+// Distinct returns a new list with the first occurrence of each value, in order.
+// Time complexity: O(n), where n is the number of elements in the list.
+func Distinct[M comparable](l *List[M]) *List[M] {
+	seen := NewSet[M]()
+	result := &List[M]{}
+	for n := l.head; n != nil; n = n.next {
+		if !seen.Contains(n.value) {
+			seen.Add(n.value)
+			result.PushRight(n.value)
+		}
+	}
+	return result
+}
+
+// Pair holds two values of possibly different types.
+type Pair[A any, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip returns a new list pairing the values of two lists by position. The result is as long as the shorter list.
+// Time complexity: O(min(n, m)), where n and m are the number of elements in the lists.
+func Zip[A any, B any](a *List[A], b *List[B]) *List[Pair[A, B]] {
+	result := &List[Pair[A, B]]{}
+	for na, nb := a.head, b.head; na != nil && nb != nil; na, nb = na.next, nb.next {
+		result.PushRight(Pair[A, B]{First: na.value, Second: nb.value})
+	}
+	return result
+}
+
+// Unzip splits a list of pairs into two new lists of the first and second values.
+// Time complexity: O(n), where n is the number of elements in the list.
+func Unzip[A any, B any](l *List[Pair[A, B]]) (*List[A], *List[B]) {
+	first := &List[A]{}
+	second := &List[B]{}
+	for n := l.head; n != nil; n = n.next {
+		first.PushRight(n.value.First)
+		second.PushRight(n.value.Second)
+	}
+	return first, second
+}
+
 // Equal returns true if the two lists are equal, false otherwise.
 // Time complexity: O(n), where n is the number of elements in the list.
 func Equal[M comparable](a, b *List[M]) bool {
@@ -394,27 +1508,14 @@ func Equal[M comparable](a, b *List[M]) bool {
 	return true
 }
 
-// Join combines multiple lists into a single list.
+// Join moves the elements of the remaining lists to the end of the first list and returns it.
+// The remaining lists are left empty.
 // Time complexity: O(m), where m is the total number of elements in all the lists.
 func Join[M any](lists ...*List[M]) *List[M] {
 	if len(lists) == 0 {
 		return NewList[M]()
 	}
-
-	if len(lists) == 1 {
-		return lists[0]
-	}
-
 	l := lists[0]
-	for _, list := range lists[1:] {
-		if list.length == 0 {
-			continue
-		}
-		l.tail.next = list.head
-		list.head.prev = l.tail
-		l.tail = list.tail
-		l.length += list.length
-	}
-
+	l.Extend(lists[1:]...)
 	return l
 }
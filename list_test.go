@@ -1,8 +1,11 @@
 package q
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
+	"slices"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -43,14 +46,54 @@ func TestSort(t *testing.T) {
 	for i := 0; i < count; i++ {
 		list.PushRight(rand.Int())
 	}
-	assert.False(list.IsSorted(LessInt))
-	list = list.Sort(LessInt)
+	assert.False(list.IsSorted(Less[int]))
+	list = list.Sort(Less[int])
 	assert.Equal(count, list.Len())
-	assert.True(list.IsSorted(LessInt))
+	assert.True(list.IsSorted(Less[int]))
 }
 
-func LessInt(a, b int) bool {
-	return a < b
+func TestSortInPlace(t *testing.T) {
+	assert := assert.New(t)
+	count := 100000
+	ascending := NewList[int]()
+	descending := NewList[int]()
+	for i := 0; i < count; i++ {
+		ascending.PushRight(i)
+		descending.PushLeft(i)
+	}
+	ascending.SortInPlace(Less[int])
+	descending.SortInPlace(Less[int])
+	assert.True(ascending.IsSorted(Less[int]))
+	assert.True(descending.IsSorted(Less[int]))
+	assert.Equal(count, descending.Len())
+	assert.Equal(0, descending.PeekLeft())
+	assert.Equal(count-1, descending.PeekRight())
+	assert.Equal(count-1, descending.Back().Value())
+	assert.Equal(count-2, descending.Back().Prev().Value())
+
+	l := NewList(3, 1, 2)
+	node := l.Front()
+	sorted := l.Sort(Less[int])
+	assert.Equal([]int{3, 1, 2}, l.Elements())
+	assert.Equal([]int{1, 2, 3}, sorted.Elements())
+	l.SortInPlace(Less[int])
+	assert.Equal([]int{1, 2, 3}, l.Elements())
+	assert.Equal(node, l.Back())
+}
+
+func TestSortStable(t *testing.T) {
+	assert := assert.New(t)
+	type pair struct{ key, order int }
+	l := NewList[pair]()
+	for i := 0; i < 1000; i++ {
+		l.PushRight(pair{key: rand.Intn(10), order: i})
+	}
+	l.SortInPlace(func(a, b pair) bool {
+		return a.key < b.key
+	})
+	assert.True(l.IsSorted(func(a, b pair) bool {
+		return a.key < b.key || (a.key == b.key && a.order < b.order)
+	}))
 }
 
 func TestListReverse(t *testing.T) {
@@ -380,3 +423,530 @@ func TestPeek(t *testing.T) {
 	assert.Zero(l.PeekLeft())
 	assert.Zero(l.PopLeft())
 }
+
+func TestListIterators(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2, 3, 4)
+
+	var indices, values []int
+	for i, v := range l.Iter() {
+		if v == 3 {
+			break
+		}
+		indices = append(indices, i)
+		values = append(values, v)
+	}
+	assert.Equal([]int{0, 1}, indices)
+	assert.Equal([]int{1, 2}, values)
+
+	indices, values = nil, nil
+	for i, v := range l.Backward() {
+		indices = append(indices, i)
+		values = append(values, v)
+	}
+	assert.Equal([]int{3, 2, 1, 0}, indices)
+	assert.Equal([]int{4, 3, 2, 1}, values)
+
+	assert.Equal([]int{1, 2, 3, 4}, slices.Collect(l.Values()))
+	assert.Empty(slices.Collect(NewList[int]().Values()))
+}
+
+func TestListNodes(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2, 3)
+	assert.Equal(1, l.Front().Value())
+	assert.Equal(3, l.Back().Value())
+	assert.Equal(2, l.Front().Next().Value())
+	assert.Nil(l.Front().Prev())
+	assert.Nil(l.Back().Next())
+	assert.Nil(NewList[int]().Front())
+
+	middle := l.Front().Next()
+	four := l.InsertAfter(4, middle)
+	l.InsertBefore(0, l.Front())
+	assert.Equal([]int{0, 1, 2, 4, 3}, l.Elements())
+	assert.Equal(5, l.Len())
+
+	assert.Equal(2, l.RemoveNode(middle))
+	assert.Nil(middle.Next())
+	assert.Equal([]int{0, 1, 4, 3}, l.Elements())
+	l.RemoveNode(middle)
+	assert.Equal(4, l.Len())
+
+	l.MoveToFront(four)
+	assert.Equal([]int{4, 0, 1, 3}, l.Elements())
+	l.MoveToBack(four)
+	assert.Equal([]int{0, 1, 3, 4}, l.Elements())
+	l.MoveAfter(l.Front(), l.Back())
+	assert.Equal([]int{1, 3, 4, 0}, l.Elements())
+	assert.Equal(0, l.PeekRight())
+	assert.Equal(1, l.PeekLeft())
+
+	other := NewList(9)
+	assert.Nil(other.InsertAfter(5, l.Front()))
+	other.MoveToFront(l.Back())
+	other.RemoveNode(l.Front())
+	assert.Equal([]int{1, 3, 4, 0}, l.Elements())
+	assert.Equal([]int{9}, other.Elements())
+}
+
+// ExampleList_MoveToFront demonstrates how to use node handles to reorder a list.
+func ExampleList_MoveToFront() {
+	list := NewList("a", "b", "c")
+	list.MoveToFront(list.Back())
+	fmt.Println(list)
+	// Output: [c a b]
+}
+
+func TestListTryPop(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(0)
+
+	value, ok := l.TryPeekLeft()
+	assert.True(ok)
+	assert.Equal(0, value)
+	value, ok = l.TryPop()
+	assert.True(ok)
+	assert.Equal(0, value)
+
+	_, ok = l.TryPop()
+	assert.False(ok)
+	_, ok = l.TryPopLeft()
+	assert.False(ok)
+	_, ok = l.TryPeekRight()
+	assert.False(ok)
+	assert.Zero(l.PopRight())
+	assert.Zero(l.PopLeft())
+	assert.Equal(0, l.Len())
+
+	_, err := l.PopErr()
+	assert.ErrorIs(err, ErrEmpty)
+	_, err = l.PopLeftErr()
+	assert.ErrorIs(err, ErrEmpty)
+	_, err = l.PeekLeftErr()
+	assert.ErrorIs(err, ErrEmpty)
+	_, err = l.PeekRightErr()
+	assert.ErrorIs(err, ErrEmpty)
+
+	l.PushRight(1, 2)
+	assert.Equal(2, l.Len())
+	value, err = l.PopRightErr()
+	assert.NoError(err)
+	assert.Equal(2, value)
+}
+
+func TestListFindOk(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(0, 1, 2)
+	value, ok := l.FindOk(func(i, value int) bool {
+		return value == 0
+	})
+	assert.True(ok)
+	assert.Equal(0, value)
+	_, ok = l.FindOk(func(i, value int) bool {
+		return value > 2
+	})
+	assert.False(ok)
+}
+
+func TestListPositional(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(0, 1, 2, 3, 4, 5)
+	for i := 0; i < l.Len(); i++ {
+		value, err := l.At(i)
+		assert.NoError(err)
+		assert.Equal(i, value)
+	}
+	value, err := l.At(-1)
+	assert.NoError(err)
+	assert.Equal(5, value)
+	_, err = l.At(6)
+	assert.ErrorIs(err, ErrOutOfRange)
+	_, err = l.At(-7)
+	assert.ErrorIs(err, ErrOutOfRange)
+	assert.EqualError(err, "q: index out of range: index -7 with length 6")
+
+	assert.NoError(l.SetAt(-2, 40))
+	assert.ErrorIs(l.SetAt(10, 0), ErrOutOfRange)
+	assert.Equal([]int{0, 1, 2, 3, 40, 5}, l.Elements())
+
+	value, err = l.RemoveAt(1)
+	assert.NoError(err)
+	assert.Equal(1, value)
+	value, err = l.RemoveAt(-1)
+	assert.NoError(err)
+	assert.Equal(5, value)
+	_, err = l.RemoveAt(4)
+	assert.ErrorIs(err, ErrOutOfRange)
+	assert.Equal([]int{0, 2, 3, 40}, l.Elements())
+
+	assert.NoError(l.InsertAt(1, 10, 11))
+	assert.NoError(l.InsertAt(l.Len(), 50))
+	assert.NoError(l.InsertAt(0, -1))
+	assert.NoError(l.InsertAt(-1, 45))
+	assert.ErrorIs(l.InsertAt(l.Len()+1, 0), ErrOutOfRange)
+	assert.Equal([]int{-1, 0, 10, 11, 2, 3, 40, 45, 50}, l.Elements())
+	assert.Equal(9, l.Len())
+	assert.Equal(50, l.PeekRight())
+
+	empty := NewList[int]()
+	assert.NoError(empty.InsertAt(0, 1, 2))
+	assert.Equal([]int{1, 2}, empty.Elements())
+}
+
+func TestBoundedList(t *testing.T) {
+	assert := assert.New(t)
+	var evicted []int
+	l := NewBoundedList(3, 1, 2, 3, 4)
+	l.OnEvict(func(value int) {
+		evicted = append(evicted, value)
+	})
+	assert.Equal(3, l.MaxLen())
+	assert.Equal([]int{2, 3, 4}, l.Elements())
+
+	l.PushRight(5, 6)
+	assert.Equal([]int{4, 5, 6}, l.Elements())
+	assert.Equal([]int{2, 3}, evicted)
+
+	l.PushLeft(0)
+	assert.Equal([]int{0, 4, 5}, l.Elements())
+	assert.Equal([]int{2, 3, 6}, evicted)
+
+	assert.NoError(l.InsertAt(1, 1))
+	assert.Equal([]int{0, 1, 4}, l.Elements())
+	assert.Equal([]int{2, 3, 6, 5}, evicted)
+	assert.NoError(l.InsertAt(2, 2))
+	assert.Equal([]int{1, 2, 4}, l.Elements())
+	assert.Equal(3, l.Len())
+
+	l.Extend(NewList(7, 8))
+	assert.Equal([]int{4, 7, 8}, l.Elements())
+	assert.Equal(3, l.Copy().MaxLen())
+
+	l.OnEvict(nil)
+	l.PushRight(9)
+	assert.Equal([]int{7, 8, 9}, l.Elements())
+	assert.Equal(0, NewList[int]().MaxLen())
+	assert.Equal(0, NewBoundedList[int](-1).MaxLen())
+}
+
+func TestBoundedListInsertNode(t *testing.T) {
+	assert := assert.New(t)
+	l := NewBoundedList(2, 1, 2)
+	node := l.InsertBefore(0, l.Front())
+	assert.Equal([]int{0, 1}, l.Elements())
+	assert.Equal(l.Front(), node)
+	assert.Equal(1, node.Next().Value())
+
+	node = l.InsertAfter(3, l.Back())
+	assert.Equal([]int{1, 3}, l.Elements())
+	assert.Equal(l.Back(), node)
+
+	single := NewBoundedList(1, 1)
+	node = single.InsertBefore(0, single.Front())
+	assert.Equal([]int{0}, single.Elements())
+	assert.Equal(single.Front(), node)
+	assert.NoError(single.InsertAt(0, 5))
+	assert.Equal([]int{5}, single.Elements())
+}
+
+func TestBoundedListSplice(t *testing.T) {
+	assert := assert.New(t)
+	var evicted []int
+	l := NewBoundedList(3, 1, 2, 3)
+	l.OnEvict(func(v int) { evicted = append(evicted, v) })
+	other := NewList(9)
+	assert.NoError(l.Splice(0, other))
+	assert.Equal([]int{9, 1, 2}, l.Elements())
+	assert.Equal(0, other.Len())
+	assert.Equal([]int{3}, evicted)
+
+	evicted = nil
+	l = NewBoundedList(4, 1, 2, 3, 4)
+	l.OnEvict(func(v int) { evicted = append(evicted, v) })
+	assert.NoError(l.Splice(1, NewList(8, 9)))
+	assert.Equal([]int{1, 8, 9, 2}, l.Elements())
+	assert.Equal([]int{4, 3}, evicted)
+	assert.NoError(l.Splice(3, NewList(7)))
+	assert.Equal([]int{8, 9, 7, 2}, l.Elements())
+	assert.Equal([]int{4, 3, 1}, evicted)
+	assert.NoError(l.Validate())
+}
+
+func TestListRotate(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2, 3, 4, 5)
+	l.Rotate(2)
+	assert.Equal([]int{4, 5, 1, 2, 3}, l.Elements())
+	l.Rotate(-3)
+	assert.Equal([]int{2, 3, 4, 5, 1}, l.Elements())
+	l.Rotate(11)
+	assert.Equal([]int{1, 2, 3, 4, 5}, l.Elements())
+	l.Rotate(0)
+	assert.Equal(1, l.Front().Value())
+	assert.Nil(l.Front().Prev())
+	assert.Equal(5, l.Back().Value())
+	assert.Nil(l.Back().Next())
+
+	single := NewList(1)
+	single.Rotate(3)
+	assert.Equal([]int{1}, single.Elements())
+}
+
+func TestListSplitAt(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2, 3, 4, 5)
+	node := l.Back()
+	right, err := l.SplitAt(2)
+	assert.NoError(err)
+	assert.Equal([]int{1, 2}, l.Elements())
+	assert.Equal([]int{3, 4, 5}, right.Elements())
+	assert.Equal(2, l.Len())
+	assert.Equal(3, right.Len())
+	assert.Equal(2, l.PeekRight())
+
+	l.RemoveNode(node)
+	assert.Equal(3, right.Len())
+	right.RemoveNode(node)
+	assert.Equal([]int{3, 4}, right.Elements())
+
+	rest, err := right.SplitAt(0)
+	assert.NoError(err)
+	assert.Equal(0, right.Len())
+	assert.Nil(right.Front())
+	assert.Equal([]int{3, 4}, rest.Elements())
+
+	empty, err := rest.SplitAt(rest.Len())
+	assert.NoError(err)
+	assert.Equal(0, empty.Len())
+	_, err = rest.SplitAt(3)
+	assert.ErrorIs(err, ErrOutOfRange)
+}
+
+func TestListSplice(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 5)
+	other := NewList(2, 3, 4)
+	node := other.Front()
+	assert.NoError(l.Splice(1, other))
+	assert.Equal([]int{1, 2, 3, 4, 5}, l.Elements())
+	assert.Equal(5, l.Len())
+	assert.Equal(0, other.Len())
+	assert.Nil(other.Front())
+
+	l.MoveToBack(node)
+	assert.Equal([]int{1, 3, 4, 5, 2}, l.Elements())
+
+	assert.NoError(l.Splice(0, NewList(0)))
+	assert.NoError(l.Splice(-1, NewList(6)))
+	assert.NoError(l.Splice(l.Len(), NewList(7)))
+	assert.Equal([]int{0, 1, 3, 4, 5, 6, 2, 7}, l.Elements())
+	assert.ErrorIs(l.Splice(9, NewList(8)), ErrOutOfRange)
+	assert.ErrorIs(l.Splice(0, l), ErrSameList)
+}
+
+func TestListExtendMoves(t *testing.T) {
+	assert := assert.New(t)
+	l1 := NewList(1)
+	l2 := NewList[int]()
+	l3 := NewList(2, 3)
+	l1.Extend(l2, l3)
+	assert.Equal([]int{1, 2, 3}, l1.Elements())
+	assert.Equal(0, l3.Len())
+
+	l1.Extend(l1)
+	assert.Equal([]int{1, 2, 3, 1, 2, 3}, l1.Elements())
+
+	joined := Join(NewList[int](), NewList(1), NewList(2))
+	assert.Equal([]int{1, 2}, joined.Elements())
+}
+
+func TestListRemoveFunc(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2, 3, 4, 5, 6)
+	var indices []int
+	removed := l.RemoveFunc(func(i, value int) bool {
+		indices = append(indices, i)
+		return value%2 == 0
+	})
+	assert.Equal(3, removed)
+	assert.Equal([]int{0, 1, 2, 3, 4, 5}, indices)
+	assert.Equal([]int{1, 3, 5}, l.Elements())
+	assert.Equal(3, l.Len())
+	assert.Equal(5, l.PeekRight())
+
+	assert.Equal(2, l.Retain(func(_, value int) bool {
+		return value == 3
+	}))
+	assert.Equal([]int{3}, l.Elements())
+	assert.Equal(3, l.PeekLeft())
+	assert.Equal(3, l.PeekRight())
+
+	assert.Equal(1, Remove(l, 3))
+	assert.Equal(0, l.Len())
+	assert.Nil(l.Front())
+	assert.Nil(l.Back())
+}
+
+func TestListRemoveFirstLast(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2, 1, 2, 1)
+	assert.True(RemoveFirst(l, 1))
+	assert.Equal([]int{2, 1, 2, 1}, l.Elements())
+	assert.True(RemoveLast(l, 2))
+	assert.Equal([]int{2, 1, 1}, l.Elements())
+	assert.False(RemoveFirst(l, 3))
+	assert.False(RemoveLast(l, 3))
+	assert.Equal(3, l.Len())
+}
+
+func TestListClear(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2, 3)
+	node := l.Front()
+	l.Clear()
+	assert.Equal(0, l.Len())
+	assert.Nil(l.Front())
+	assert.Nil(node.Next())
+	l.RemoveNode(node)
+	assert.Equal(0, l.Len())
+	l.PushRight(4)
+	assert.Equal([]int{4}, l.Elements())
+}
+
+func TestScan(t *testing.T) {
+	assert := assert.New(t)
+	sums := Scan(NewList(1, 2, 3, 4), func(acc, value int) int {
+		return acc + value
+	}, 10)
+	assert.Equal([]int{11, 13, 16, 20}, sums.Elements())
+	assert.Equal(0, Scan(NewList[int](), func(acc, value int) int { return acc }, 0).Len())
+}
+
+func TestFlatMapFlatten(t *testing.T) {
+	assert := assert.New(t)
+	repeated := FlatMap(NewList(1, 2, 3), func(value int) *List[int] {
+		return NewList(slices.Repeat([]int{value}, value)...)
+	})
+	assert.Equal([]int{1, 2, 2, 3, 3, 3}, repeated.Elements())
+
+	flat := Flatten(NewList(NewList(1, 2), NewList[int](), NewList(3)))
+	assert.Equal([]int{1, 2, 3}, flat.Elements())
+	assert.Equal(3, flat.Len())
+}
+
+func TestPartitionGroupBy(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2, 3, 4, 5)
+	even, odd := Partition(l, func(value int) bool {
+		return value%2 == 0
+	})
+	assert.Equal([]int{2, 4}, even.Elements())
+	assert.Equal([]int{1, 3, 5}, odd.Elements())
+
+	groups := GroupBy(l, func(value int) int {
+		return value % 3
+	})
+	assert.Len(groups, 3)
+	assert.Equal([]int{3}, groups[0].Elements())
+	assert.Equal([]int{1, 4}, groups[1].Elements())
+	assert.Equal([]int{2, 5}, groups[2].Elements())
+}
+
+func TestChunkWindow(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2, 3, 4, 5)
+	chunks := Map(Chunk(l, 2), (*List[int]).Elements)
+	assert.Equal([][]int{{1, 2}, {3, 4}, {5}}, chunks.Elements())
+	assert.Equal(0, Chunk(NewList[int](), 2).Len())
+
+	windows := Map(Window(l, 3), (*List[int]).Elements)
+	assert.Equal([][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, windows.Elements())
+	assert.Equal(0, Window(l, 6).Len())
+
+	assert.Panics(func() { Chunk(l, 0) })
+	assert.Panics(func() { Window(l, 0) })
+}
+
+func TestTakeDropWhile(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2, 3, 1)
+	small := func(value int) bool {
+		return value < 3
+	}
+	assert.Equal([]int{1, 2}, TakeWhile(l, small).Elements())
+	assert.Equal([]int{3, 1}, DropWhile(l, small).Elements())
+	assert.Equal(0, DropWhile(NewList(1, 2), small).Len())
+}
+
+func TestDistinct(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal([]int{3, 1, 2}, Distinct(NewList(3, 1, 3, 2, 1)).Elements())
+}
+
+func TestZipUnzip(t *testing.T) {
+	assert := assert.New(t)
+	zipped := Zip(NewList(1, 2, 3), NewList("a", "b"))
+	assert.Equal([]Pair[int, string]{{1, "a"}, {2, "b"}}, zipped.Elements())
+
+	numbers, letters := Unzip(zipped)
+	assert.Equal([]int{1, 2}, numbers.Elements())
+	assert.Equal([]string{"a", "b"}, letters.Elements())
+}
+
+func TestParallelMapFilterReduce(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	count := 100000
+	l := NewList[int]()
+	for i := 0; i < count; i++ {
+		l.PushRight(i)
+	}
+
+	doubled, err := ParallelMap(ctx, l, func(value int) int {
+		return value * 2
+	})
+	assert.NoError(err)
+	assert.Equal(Map(l, func(value int) int { return value * 2 }).Elements(), doubled.Elements())
+
+	even, err := ParallelFilter(ctx, l, func(value int) bool {
+		return value%2 == 0
+	})
+	assert.NoError(err)
+	assert.Equal(count/2, even.Len())
+	assert.True(even.IsSorted(Less[int]))
+
+	sum, err := ParallelReduce(ctx, l, func(acc, value int) int {
+		return acc + value
+	}, func(a, b int) int {
+		return a + b
+	}, 0)
+	assert.NoError(err)
+	assert.Equal(count*(count-1)/2, sum)
+
+	empty, err := ParallelMap(ctx, NewList[int](), func(value int) int { return value })
+	assert.NoError(err)
+	assert.Equal(0, empty.Len())
+}
+
+func TestParallelCancel(t *testing.T) {
+	assert := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	l := NewList(slices.Repeat([]int{1}, 10000)...)
+	var calls atomic.Int64
+	_, err := ParallelMap(ctx, l, func(value int) int {
+		if calls.Add(1) == 100 {
+			cancel()
+		}
+		return value
+	})
+	assert.ErrorIs(err, context.Canceled)
+	assert.Less(calls.Load(), int64(10000))
+
+	_, err = ParallelReduce(ctx, l, func(acc, value int) int {
+		return acc + value
+	}, func(a, b int) int {
+		return a + b
+	}, 0)
+	assert.ErrorIs(err, context.Canceled)
+}
@@ -0,0 +1,88 @@
+package q
+
+import "sync/atomic"
+
+// LockFreeQueue is an unbounded multi-producer, multi-consumer FIFO queue that uses atomic operations instead of
+// locks, implemented as a Michael-Scott queue. It must be created with NewLockFreeQueue.
+type LockFreeQueue[M any] struct {
+	head   atomic.Pointer[lockFreeNode[M]]
+	tail   atomic.Pointer[lockFreeNode[M]]
+	length atomic.Int64
+}
+
+// lockFreeNode represents a node in the lock-free queue. The head of the queue is always a sentinel node
+// whose value has already been dequeued.
+type lockFreeNode[M any] struct {
+	value M
+	next  atomic.Pointer[lockFreeNode[M]]
+}
+
+// NewLockFreeQueue creates a new LockFreeQueue and initializes it with the given elements.
+// Time complexity: O(n), where n is the number of elements.
+func NewLockFreeQueue[M any](elements ...M) *LockFreeQueue[M] {
+	queue := &LockFreeQueue[M]{}
+	sentinel := &lockFreeNode[M]{}
+	queue.head.Store(sentinel)
+	queue.tail.Store(sentinel)
+	for _, element := range elements {
+		queue.Enqueue(element)
+	}
+	return queue
+}
+
+// Enqueue adds a value to the end of the queue.
+// Time complexity: O(1), plus retries under contention.
+func (lq *LockFreeQueue[M]) Enqueue(value M) {
+	node := &lockFreeNode[M]{value: value}
+	for {
+		tail := lq.tail.Load()
+		next := tail.next.Load()
+		if tail != lq.tail.Load() {
+			continue
+		}
+		if next != nil {
+			// The tail is lagging behind; help the other producer advance it.
+			lq.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, node) {
+			lq.tail.CompareAndSwap(tail, node)
+			lq.length.Add(1)
+			return
+		}
+	}
+}
+
+// Dequeue removes and returns the value at the front of the queue. It returns false if the queue is empty.
+// Time complexity: O(1), plus retries under contention.
+func (lq *LockFreeQueue[M]) Dequeue() (M, bool) {
+	for {
+		head := lq.head.Load()
+		tail := lq.tail.Load()
+		next := head.next.Load()
+		if head != lq.head.Load() {
+			continue
+		}
+		if next == nil {
+			var m M
+			return m, false
+		}
+		if head == tail {
+			// The tail is lagging behind; help the producer advance it.
+			lq.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		value := next.value
+		if lq.head.CompareAndSwap(head, next) {
+			lq.length.Add(-1)
+			return value, true
+		}
+	}
+}
+
+// Len returns the approximate number of values in the queue. Under concurrent use the result may already be
+// out of date when it is returned.
+// Time complexity: O(1).
+func (lq *LockFreeQueue[M]) Len() int {
+	return int(max(lq.length.Load(), 0))
+}
@@ -0,0 +1,85 @@
+package q
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockFreeQueue(t *testing.T) {
+	assert := assert.New(t)
+	lq := NewLockFreeQueue(1, 2)
+	lq.Enqueue(3)
+	assert.Equal(3, lq.Len())
+	for i := 1; i <= 3; i++ {
+		value, ok := lq.Dequeue()
+		assert.True(ok)
+		assert.Equal(i, value)
+	}
+	_, ok := lq.Dequeue()
+	assert.False(ok)
+	assert.Equal(0, lq.Len())
+}
+
+func TestLockFreeQueueStress(t *testing.T) {
+	assert := assert.New(t)
+	const producers = 8
+	const consumers = 8
+	const perProducer = 5000
+	type item struct{ producer, seq int }
+
+	lq := NewLockFreeQueue[item]()
+	var produced sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		produced.Add(1)
+		go func() {
+			defer produced.Done()
+			for i := 0; i < perProducer; i++ {
+				lq.Enqueue(item{producer: p, seq: i})
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	results := make([][]item, consumers)
+	var consumed sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		consumed.Add(1)
+		go func() {
+			defer consumed.Done()
+			for {
+				value, ok := lq.Dequeue()
+				if ok {
+					results[c] = append(results[c], value)
+					continue
+				}
+				select {
+				case <-done:
+					if lq.Len() == 0 {
+						return
+					}
+				default:
+				}
+			}
+		}()
+	}
+	produced.Wait()
+	close(done)
+	consumed.Wait()
+
+	seen := make(map[item]bool)
+	for _, result := range results {
+		last := make(map[int]int)
+		for _, it := range result {
+			assert.False(seen[it], "duplicate %v", it)
+			seen[it] = true
+			if previous, ok := last[it.producer]; ok {
+				assert.Less(previous, it.seq, "out of order for producer %d", it.producer)
+			}
+			last[it.producer] = it.seq
+		}
+	}
+	assert.Len(seen, producers*perProducer)
+	assert.Equal(0, lq.Len())
+}
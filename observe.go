@@ -0,0 +1,127 @@
+package q
+
+import (
+	"fmt"
+	"slices"
+)
+
+// EventKind is the kind of change described by an Event.
+type EventKind int
+
+const (
+	// EventInserted reports that a value was added to the collection.
+	EventInserted EventKind = iota
+	// EventRemoved reports that a value was removed from the collection.
+	EventRemoved
+	// EventCleared reports that all the values were removed from the collection at once.
+	EventCleared
+	// EventCountChanged reports that the count of a value in a Counter changed.
+	EventCountChanged
+	// EventReordered reports that the values of a List were rearranged without being added or removed.
+	EventReordered
+)
+
+// String returns the name of the event kind.
+func (k EventKind) String() string {
+	switch k {
+	case EventInserted:
+		return "inserted"
+	case EventRemoved:
+		return "removed"
+	case EventCleared:
+		return "cleared"
+	case EventCountChanged:
+		return "count changed"
+	case EventReordered:
+		return "reordered"
+	}
+	return fmt.Sprintf("EventKind(%d)", int(k))
+}
+
+// Event describes a single change to a collection. Value is the value that was inserted, removed or counted.
+// Index is the value's position in a List and -1 for other collections and for events without a single position.
+// OldCount and NewCount are set for EventCountChanged.
+type Event[M any] struct {
+	Kind     EventKind
+	Value    M
+	Index    int
+	OldCount int
+	NewCount int
+}
+
+// String returns a short description of the event.
+func (e Event[M]) String() string {
+	switch e.Kind {
+	case EventCleared, EventReordered:
+		return e.Kind.String()
+	case EventCountChanged:
+		return fmt.Sprintf("%v %v %d->%d", e.Kind, e.Value, e.OldCount, e.NewCount)
+	}
+	if e.Index >= 0 {
+		return fmt.Sprintf("%v %v at %d", e.Kind, e.Value, e.Index)
+	}
+	return fmt.Sprintf("%v %v", e.Kind, e.Value)
+}
+
+// observerList holds the observers registered on a collection, in registration order.
+// A collection without observers has an empty list, so emitting costs a single length check.
+type observerList[M any] []*func(Event[M])
+
+// add registers an observer and returns a function that unregisters it.
+// Time complexity: O(1) to register, O(k) to unregister, where k is the number of observers.
+func (o *observerList[M]) add(observer func(Event[M])) func() {
+	entry := &observer
+	*o = append(*o, entry)
+	return func() {
+		remaining := slices.DeleteFunc(slices.Clone(*o), func(e *func(Event[M])) bool {
+			return e == entry
+		})
+		if len(remaining) == 0 {
+			remaining = nil
+		}
+		*o = remaining
+	}
+}
+
+// emit delivers an event to every observer.
+// Time complexity: O(k), where k is the number of observers.
+func (o observerList[M]) emit(event Event[M]) {
+	for _, observer := range o {
+		(*observer)(event)
+	}
+}
+
+// Observe registers a callback that receives an event after each change to the list and returns a function that
+// unregisters it. Insertions and removals report the value's index, which is found by walking the list from the
+// front, so each change away from the ends costs O(n) while an observer is registered. Events are delivered as each
+// element is inserted or removed, not once a bulk operation has completed, so the callback may see intermediate
+// state: RemoveFunc reports each removal as it happens, and a full bounded list briefly holds one value more than
+// its maximum length before the eviction is reported. The callback must not modify the list.
+// Time complexity: O(1).
+func (l *List[M]) Observe(callback func(Event[M])) (cancel func()) {
+	return l.observers.add(callback)
+}
+
+// Observe registers a callback that receives an event after each change to the set and returns a function that
+// unregisters it. Adding a value that is already present or removing one that is not reports no event.
+// The callback must not modify the set.
+// Time complexity: O(1).
+func (s *Set[T]) Observe(callback func(Event[T])) (cancel func()) {
+	return s.observers.add(callback)
+}
+
+// Observe registers a callback that receives an EventCountChanged after each change to the count of a value, and an
+// EventCleared when the counter is cleared, and returns a function that unregisters it.
+// The callback must not modify the counter.
+// Time complexity: O(1).
+func (c *Counter[T]) Observe(callback func(Event[T])) (cancel func()) {
+	return c.observers.add(callback)
+}
+
+// Observe registers a callback that receives an event after each value is pushed onto or popped from the heap and
+// returns a function that unregisters it. Heap events have no index.
+// The callback must not modify the heap.
+// Time complexity: O(1).
+func (h *Heap[M]) Observe(callback func(Event[M])) (cancel func()) {
+	return h.observers.add(callback)
+}
@@ -0,0 +1,209 @@
+package q
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// record returns a callback that appends the string form of each event to events.
+func record[M any](events *[]string) func(Event[M]) {
+	return func(e Event[M]) {
+		*events = append(*events, e.String())
+	}
+}
+
+func TestListObserve(t *testing.T) {
+	assert := assert.New(t)
+	var events []string
+	l := NewList(1, 2, 3)
+	cancel := l.Observe(record[int](&events))
+
+	l.PushRight(4)
+	l.PushLeft(0)
+	assert.NoError(l.InsertAt(2, 9))
+	l.PopLeft()
+	assert.NoError(l.SetAt(0, 5))
+	RemoveFirst(l, 9)
+	l.Reverse()
+	l.Clear()
+	assert.Equal([]string{
+		"inserted 4 at 3",
+		"inserted 0 at 0",
+		"inserted 9 at 2",
+		"removed 0 at 0",
+		"removed 1 at 0",
+		"inserted 5 at 0",
+		"removed 9 at 1",
+		"reordered",
+		"cleared",
+	}, events)
+
+	cancel()
+	cancel()
+	events = nil
+	l.PushRight(1)
+	assert.Empty(events)
+	assert.Nil(l.observers)
+}
+
+func TestListObserveMoves(t *testing.T) {
+	assert := assert.New(t)
+	var from, to []string
+	l := NewList(1, 2, 3, 4)
+	other := NewList[int]()
+	l.Observe(record[int](&from))
+	other.Observe(record[int](&to))
+
+	split, err := l.SplitAt(2)
+	assert.NoError(err)
+	assert.Equal([]string{"removed 3 at 2", "removed 4 at 2"}, from)
+	assert.NoError(other.Splice(0, split))
+	assert.Equal([]string{"inserted 3 at 0", "inserted 4 at 1"}, to)
+
+	from = nil
+	bounded := NewBoundedList(2, 1, 2)
+	bounded.Observe(record[int](&from))
+	bounded.PushRight(3)
+	assert.Equal([]string{"inserted 3 at 2", "removed 1 at 0"}, from)
+}
+
+func TestListObserveRollback(t *testing.T) {
+	assert := assert.New(t)
+	var events []string
+	l := NewList(1, 2)
+	l.Observe(record[int](&events))
+	tx := l.Begin()
+	l.PushRight(3)
+	l.Clear()
+	assert.NoError(tx.Rollback())
+	assert.Equal([]string{
+		"inserted 3 at 2",
+		"cleared",
+		"inserted 1 at 0",
+		"inserted 2 at 1",
+		"inserted 3 at 2",
+		"removed 3 at 2",
+	}, events)
+}
+
+func TestListObserveIntermediate(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2, 3, 4)
+	var lengths []int
+	l.Observe(func(Event[int]) { lengths = append(lengths, l.Len()) })
+	l.RemoveFunc(func(_ int, value int) bool { return value%2 == 0 })
+	assert.Equal([]int{3, 2}, lengths)
+
+	bounded := NewBoundedList[int](2)
+	bounded.PushRight(1, 2)
+	lengths = nil
+	bounded.Observe(func(Event[int]) { lengths = append(lengths, bounded.Len()) })
+	bounded.PushRight(3)
+	assert.Equal([]int{3, 2}, lengths)
+}
+
+func TestSetObserve(t *testing.T) {
+	assert := assert.New(t)
+	var events []string
+	s := NewSet(1)
+	cancel := s.Observe(record[int](&events))
+	s.Add(1, 2)
+	s.Remove(3)
+	s.Remove(1)
+	s.Clear()
+	cancel()
+	s.Add(4)
+	assert.Equal([]string{"inserted 2", "removed 1", "cleared"}, events)
+}
+
+func TestCounterObserve(t *testing.T) {
+	assert := assert.New(t)
+	var events []string
+	c := NewCounter("a")
+	c.Observe(record[string](&events))
+	c.Add("a", "b")
+	c.Remove("a", "c")
+	c.Clear()
+	assert.Equal([]string{
+		"count changed a 1->2",
+		"count changed b 0->1",
+		"count changed a 2->1",
+		"cleared",
+	}, events)
+}
+
+func TestCounterObserveDecode(t *testing.T) {
+	assert := assert.New(t)
+	var events []string
+	c := NewCounter[string]()
+	c.Observe(record[string](&events))
+	assert.NoError(json.Unmarshal([]byte(`{"a":2,"b":1}`), c))
+	slices.Sort(events[1:])
+	assert.Equal([]string{"cleared", "count changed a 0->2", "count changed b 0->1"}, events)
+
+	data, err := NewCounter("c", "c").MarshalBinary()
+	assert.NoError(err)
+	events = nil
+	tx := c.Begin()
+	assert.NoError(c.UnmarshalBinary(data))
+	assert.Equal([]string{"cleared", "count changed c 0->2"}, events)
+	events = nil
+	assert.NoError(tx.Rollback())
+	slices.Sort(events[1:])
+	assert.Equal([]string{"count changed c 2->0", "count changed a 0->2", "count changed b 0->1"}, events)
+	assert.Equal(3, c.Len())
+	assert.Equal(2, c.Count("a"))
+}
+
+func TestHeapObserve(t *testing.T) {
+	assert := assert.New(t)
+	var events []string
+	h := NewMinHeap(3)
+	h.Observe(record[int](&events))
+	h.Push(1)
+	h.Pop()
+	h.TryPop()
+	h.TryPop()
+	assert.NoError(h.UnmarshalJSON([]byte("[5]")))
+	assert.Equal([]string{"inserted 1", "removed 1", "removed 3", "cleared", "inserted 5"}, events)
+}
+
+func TestObserveMultiple(t *testing.T) {
+	assert := assert.New(t)
+	var first, second []string
+	l := NewList[int]()
+	cancelFirst := l.Observe(record[int](&first))
+	l.Observe(record[int](&second))
+	l.PushRight(1)
+	cancelFirst()
+	l.PushRight(2)
+	assert.Equal([]string{"inserted 1 at 0"}, first)
+	assert.Equal([]string{"inserted 1 at 0", "inserted 2 at 1"}, second)
+}
+
+// ExampleCounter_Observe demonstrates how to keep a secondary index in sync with a counter.
+func ExampleCounter_Observe() {
+	c := NewCounter[string]()
+	popular := NewSet[string]()
+	c.Observe(func(e Event[string]) {
+		if e.Kind != EventCountChanged {
+			return
+		}
+		if e.NewCount >= 2 {
+			popular.Add(e.Value)
+		} else {
+			popular.Remove(e.Value)
+		}
+	})
+	c.Add("go", "rust", "go")
+	fmt.Println(popular)
+	c.Remove("go")
+	fmt.Println(popular)
+	// Output:
+	// [go]
+	// []
+}
@@ -0,0 +1,205 @@
+package q
+
+import (
+	"fmt"
+	"iter"
+)
+
+// PList is a generic persistent (immutable) singly linked list. Operations return new versions that share
+// structure with the original, so every version stays valid and is safe to read from any goroutine.
+// The zero value is an empty list.
+type PList[M any] struct {
+	head   *pcell[M]
+	length int
+}
+
+// pcell represents an immutable cell in a persistent list.
+type pcell[M any] struct {
+	value M
+	next  *pcell[M]
+}
+
+// NewPList creates a new PList with the given elements.
+// Time complexity: O(n), where n is the number of elements.
+func NewPList[M any](elements ...M) PList[M] {
+	return prepend(elements, PList[M]{})
+}
+
+// prepend returns a list of the values followed by the cells of tail, which are shared.
+// Time complexity: O(n), where n is the number of values.
+func prepend[M any](values []M, tail PList[M]) PList[M] {
+	for i := len(values) - 1; i >= 0; i-- {
+		tail = PList[M]{head: &pcell[M]{value: values[i], next: tail.head}, length: tail.length + 1}
+	}
+	return tail
+}
+
+// Len returns the length of the list.
+// Time complexity: O(1).
+func (p PList[M]) Len() int {
+	return p.length
+}
+
+// PushLeft returns a new list with values added to the beginning, in the same order as List.PushLeft.
+// Time complexity: O(n), where n is the number of values.
+func (p PList[M]) PushLeft(values ...M) PList[M] {
+	for _, v := range values {
+		p = PList[M]{head: &pcell[M]{value: v, next: p.head}, length: p.length + 1}
+	}
+	return p
+}
+
+// Push returns a new list with values added to the end.
+// Time complexity: O(n+m), where n is the length of the list and m is the number of values.
+func (p PList[M]) Push(values ...M) PList[M] {
+	return p.PushRight(values...)
+}
+
+// PushRight returns a new list with values added to the end.
+// Time complexity: O(n+m), where n is the length of the list and m is the number of values.
+func (p PList[M]) PushRight(values ...M) PList[M] {
+	return p.Concat(NewPList(values...))
+}
+
+// PopLeft returns the first value and a new list without it.
+// If the list is empty, it returns the zero value and an empty list.
+// Time complexity: O(1).
+func (p PList[M]) PopLeft() (M, PList[M]) {
+	value, rest, _ := p.TryPopLeft()
+	return value, rest
+}
+
+// TryPopLeft returns the first value and a new list without it. It returns false if the list is empty.
+// Time complexity: O(1).
+func (p PList[M]) TryPopLeft() (M, PList[M], bool) {
+	if p.head == nil {
+		var m M
+		return m, p, false
+	}
+	return p.head.value, PList[M]{head: p.head.next, length: p.length - 1}, true
+}
+
+// PeekLeft returns the first value in the list.
+// Time complexity: O(1).
+func (p PList[M]) PeekLeft() M {
+	value, _ := p.TryPeekLeft()
+	return value
+}
+
+// TryPeekLeft returns the first value in the list. It returns false if the list is empty.
+// Time complexity: O(1).
+func (p PList[M]) TryPeekLeft() (M, bool) {
+	if p.head == nil {
+		var m M
+		return m, false
+	}
+	return p.head.value, true
+}
+
+// At returns the value at index i. Negative indices count back from the end of the list.
+// It returns ErrOutOfRange if the index is outside the list.
+// Time complexity: O(i).
+func (p PList[M]) At(i int) (M, error) {
+	i, err := resolveIndex(i, p.length, false)
+	if err != nil {
+		var m M
+		return m, err
+	}
+	c := p.head
+	for ; i > 0; i-- {
+		c = c.next
+	}
+	return c.value, nil
+}
+
+// Concat returns a new list with the values of the list followed by the values of other, whose cells are shared.
+// Time complexity: O(n), where n is the length of the list.
+func (p PList[M]) Concat(other PList[M]) PList[M] {
+	if other.length == 0 {
+		return p
+	}
+	return prepend(p.Elements(), other)
+}
+
+// Reverse returns a new list with the values in reverse order.
+// Time complexity: O(n), where n is the length of the list.
+func (p PList[M]) Reverse() PList[M] {
+	var result PList[M]
+	for c := p.head; c != nil; c = c.next {
+		result = result.PushLeft(c.value)
+	}
+	return result
+}
+
+// Filter returns a new list containing only the values that satisfy the callback function.
+// The cells after the last removed value are shared with the original list.
+// Time complexity: O(n), where n is the length of the list.
+func (p PList[M]) Filter(callback func(int, M) bool) PList[M] {
+	var kept []M
+	shared := p
+	i := 0
+	for c := p.head; c != nil; c = c.next {
+		if !callback(i, c.value) {
+			for d := shared.head; d != c; d = d.next {
+				kept = append(kept, d.value)
+			}
+			shared = PList[M]{head: c.next, length: p.length - i - 1}
+		}
+		i++
+	}
+	return prepend(kept, shared)
+}
+
+// Values returns an iterator over the values in the list, from left to right.
+// Time complexity: O(n), where n is the length of the list.
+func (p PList[M]) Values() iter.Seq[M] {
+	return func(yield func(M) bool) {
+		for c := p.head; c != nil; c = c.next {
+			if !yield(c.value) {
+				return
+			}
+		}
+	}
+}
+
+// Elements returns a slice containing all the values in the list.
+// Time complexity: O(n), where n is the length of the list.
+func (p PList[M]) Elements() []M {
+	result := make([]M, 0, p.length)
+	for c := p.head; c != nil; c = c.next {
+		result = append(result, c.value)
+	}
+	return result
+}
+
+// ToList returns a new mutable List with the values of the list.
+// Time complexity: O(n), where n is the length of the list.
+func (p PList[M]) ToList() *List[M] {
+	return NewList(p.Elements()...)
+}
+
+// String returns a string representation of the list.
+// Time complexity: O(n), where n is the length of the list.
+func (p PList[M]) String() string {
+	return fmt.Sprintf("%v", p.Elements())
+}
+
+// MapPList applies a callback function to each value in the list and returns a new list with the results.
+// Time complexity: O(n), where n is the length of the list.
+func MapPList[M any, N any](p PList[M], callback func(M) N) PList[N] {
+	values := make([]N, 0, p.length)
+	for c := p.head; c != nil; c = c.next {
+		values = append(values, callback(c.value))
+	}
+	return NewPList(values...)
+}
+
+// ReducePList applies a callback function to each value in the list and returns a single accumulated value.
+// Time complexity: O(n), where n is the length of the list.
+func ReducePList[M any, N any](p PList[M], callback func(N, M) N, initial N) N {
+	acc := initial
+	for c := p.head; c != nil; c = c.next {
+		acc = callback(acc, c.value)
+	}
+	return acc
+}
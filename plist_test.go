@@ -0,0 +1,104 @@
+package q
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPList(t *testing.T) {
+	assert := assert.New(t)
+	var empty PList[int]
+	assert.Equal(0, empty.Len())
+	_, _, ok := empty.TryPopLeft()
+	assert.False(ok)
+
+	v1 := NewPList(2, 3)
+	v2 := v1.PushLeft(1)
+	v3 := v2.Push(4)
+	value, v4 := v3.PopLeft()
+	assert.Equal(1, value)
+
+	assert.Equal([]int{2, 3}, v1.Elements())
+	assert.Equal([]int{1, 2, 3}, v2.Elements())
+	assert.Equal([]int{1, 2, 3, 4}, v3.Elements())
+	assert.Equal([]int{2, 3, 4}, v4.Elements())
+	assert.Equal(3, v4.Len())
+	assert.Equal(v1.head, v2.head.next)
+
+	assert.Equal([]int{3, 2}, v1.Reverse().Elements())
+	assert.Equal(1, v2.PeekLeft())
+	at, err := v3.At(-1)
+	assert.NoError(err)
+	assert.Equal(4, at)
+	_, err = v3.At(4)
+	assert.ErrorIs(err, ErrOutOfRange)
+	assert.Equal("[1 2 3 4]", v3.String())
+}
+
+func TestPListConcatShares(t *testing.T) {
+	assert := assert.New(t)
+	left := NewPList(1, 2)
+	right := NewPList(3, 4)
+	joined := left.Concat(right)
+	assert.Equal([]int{1, 2, 3, 4}, joined.Elements())
+	assert.Equal(right.head, joined.head.next.next)
+	assert.Equal([]int{1, 2}, left.Elements())
+}
+
+func TestPListFilterMapReduce(t *testing.T) {
+	assert := assert.New(t)
+	p := NewPList(1, 2, 3, 4, 5, 6)
+	odd := p.Filter(func(_, value int) bool {
+		return value%2 == 1
+	})
+	assert.Equal([]int{1, 3, 5}, odd.Elements())
+	assert.Equal(3, odd.Len())
+
+	tail := p.Filter(func(i, _ int) bool {
+		return i != 1
+	})
+	assert.Equal([]int{1, 3, 4, 5, 6}, tail.Elements())
+	assert.Equal(p.head.next.next, tail.head.next)
+	assert.Equal(p.head, p.Filter(func(int, int) bool { return true }).head)
+
+	doubled := MapPList(p, func(value int) string {
+		return fmt.Sprint(value * 2)
+	})
+	assert.Equal([]string{"2", "4", "6", "8", "10", "12"}, doubled.Elements())
+	assert.Equal(21, ReducePList(p, func(acc, value int) int {
+		return acc + value
+	}, 0))
+}
+
+func TestPListConversion(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2, 3)
+	p := l.ToPList()
+	l.PushRight(4)
+	assert.Equal([]int{1, 2, 3}, p.Elements())
+	assert.Equal([]int{1, 2, 3}, p.ToList().Elements())
+	assert.Equal([]int{1, 2, 3}, slices.Collect(p.Values()))
+}
+
+func TestPListConcurrentReads(t *testing.T) {
+	assert := assert.New(t)
+	base := NewPList(slices.Repeat([]int{1}, 100)...)
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			version := base.PushLeft(w)
+			assert.Equal(101, version.Len())
+			assert.Equal(100+w, ReducePList(version, func(acc, value int) int {
+				return acc + value
+			}, 0))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(100, base.Len())
+}
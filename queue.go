@@ -0,0 +1,168 @@
+package q
+
+import (
+	"context"
+	"sync"
+)
+
+// BlockingQueue is a FIFO queue for producers and consumers in different goroutines, backed by a List.
+// An optional capacity makes producers wait for room. Once closed, consumers drain the remaining values
+// and then receive ErrClosed.
+type BlockingQueue[M any] struct {
+	mu       sync.Mutex
+	list     *List[M]
+	capacity int
+	closed   bool
+	changed  chan struct{}
+}
+
+// NewBlockingQueue creates a new BlockingQueue that holds at most capacity values.
+// A capacity less than 1 means the queue is unbounded.
+// Time complexity: O(1).
+func NewBlockingQueue[M any](capacity int) *BlockingQueue[M] {
+	return &BlockingQueue[M]{
+		list:     NewList[M](),
+		capacity: max(capacity, 0),
+		changed:  make(chan struct{}),
+	}
+}
+
+// Put adds a value to the end of the queue, waiting for room if the queue is at capacity.
+// It returns ErrClosed if the queue is closed, or the context's error if ctx is done first.
+// Time complexity: O(1), plus any time spent waiting.
+func (bq *BlockingQueue[M]) Put(ctx context.Context, value M) error {
+	for {
+		bq.mu.Lock()
+		if bq.closed {
+			bq.mu.Unlock()
+			return ErrClosed
+		}
+		if bq.capacity == 0 || bq.list.Len() < bq.capacity {
+			bq.list.PushRight(value)
+			bq.broadcast()
+			bq.mu.Unlock()
+			return nil
+		}
+		changed := bq.changed
+		bq.mu.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// TryPut adds a value to the end of the queue without waiting.
+// It returns ErrFull if the queue is at capacity and ErrClosed if the queue is closed.
+// Time complexity: O(1).
+func (bq *BlockingQueue[M]) TryPut(value M) error {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	if bq.closed {
+		return ErrClosed
+	}
+	if bq.capacity > 0 && bq.list.Len() >= bq.capacity {
+		return ErrFull
+	}
+	bq.list.PushRight(value)
+	bq.broadcast()
+	return nil
+}
+
+// Take removes and returns the value at the front of the queue, waiting for one if the queue is empty.
+// It returns ErrClosed if the queue is closed and drained, or the context's error if ctx is done first.
+// Time complexity: O(1), plus any time spent waiting.
+func (bq *BlockingQueue[M]) Take(ctx context.Context) (M, error) {
+	for {
+		bq.mu.Lock()
+		if value, ok := bq.list.TryPopLeft(); ok {
+			bq.broadcast()
+			bq.mu.Unlock()
+			return value, nil
+		}
+		if bq.closed {
+			bq.mu.Unlock()
+			var m M
+			return m, ErrClosed
+		}
+		changed := bq.changed
+		bq.mu.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			var m M
+			return m, ctx.Err()
+		}
+	}
+}
+
+// TryTake removes and returns the value at the front of the queue without waiting.
+// It returns false if the queue is empty.
+// Time complexity: O(1).
+func (bq *BlockingQueue[M]) TryTake() (M, bool) {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	value, ok := bq.list.TryPopLeft()
+	if ok {
+		bq.broadcast()
+	}
+	return value, ok
+}
+
+// Peek returns the value at the front of the queue without removing it. It returns false if the queue is empty.
+// Time complexity: O(1).
+func (bq *BlockingQueue[M]) Peek() (M, bool) {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.list.TryPeekLeft()
+}
+
+// Close closes the queue. Further puts fail with ErrClosed, and takes fail with ErrClosed once the queue is drained.
+// Waiting producers and consumers are woken up. Closing a closed queue has no effect.
+// Time complexity: O(1).
+func (bq *BlockingQueue[M]) Close() {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	if !bq.closed {
+		bq.closed = true
+		bq.broadcast()
+	}
+}
+
+// Closed reports whether the queue has been closed.
+// Time complexity: O(1).
+func (bq *BlockingQueue[M]) Closed() bool {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.closed
+}
+
+// Len returns the number of values in the queue.
+// Time complexity: O(1).
+func (bq *BlockingQueue[M]) Len() int {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.list.Len()
+}
+
+// Cap returns the capacity of the queue, or 0 if the queue is unbounded.
+// Time complexity: O(1).
+func (bq *BlockingQueue[M]) Cap() int {
+	return bq.capacity
+}
+
+// Elements returns a slice containing the values in the queue, from front to back.
+// Time complexity: O(n), where n is the number of values in the queue.
+func (bq *BlockingQueue[M]) Elements() []M {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.list.Elements()
+}
+
+// broadcast wakes every goroutine waiting for the queue to change. The lock must be held.
+// Time complexity: O(1).
+func (bq *BlockingQueue[M]) broadcast() {
+	close(bq.changed)
+	bq.changed = make(chan struct{})
+}
@@ -0,0 +1,124 @@
+package q
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockingQueue(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	bq := NewBlockingQueue[int](2)
+	assert.NoError(bq.Put(ctx, 1))
+	assert.NoError(bq.TryPut(2))
+	assert.ErrorIs(bq.TryPut(3), ErrFull)
+	assert.Equal(2, bq.Len())
+	assert.Equal(2, bq.Cap())
+	assert.Equal([]int{1, 2}, bq.Elements())
+
+	value, ok := bq.Peek()
+	assert.True(ok)
+	assert.Equal(1, value)
+
+	value, err := bq.Take(ctx)
+	assert.NoError(err)
+	assert.Equal(1, value)
+	value, ok = bq.TryTake()
+	assert.True(ok)
+	assert.Equal(2, value)
+	_, ok = bq.TryTake()
+	assert.False(ok)
+}
+
+func TestBlockingQueueWaits(t *testing.T) {
+	assert := assert.New(t)
+	bq := NewBlockingQueue[int](1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := bq.Take(ctx)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+
+	assert.NoError(bq.TryPut(1))
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(bq.Put(ctx, 2), context.DeadlineExceeded)
+
+	done := make(chan error)
+	go func() {
+		done <- bq.Put(context.Background(), 2)
+	}()
+	value, err := bq.Take(context.Background())
+	assert.NoError(err)
+	assert.Equal(1, value)
+	assert.NoError(<-done)
+	assert.Equal([]int{2}, bq.Elements())
+}
+
+func TestBlockingQueueClose(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	bq := NewBlockingQueue[int](0)
+	assert.NoError(bq.Put(ctx, 1))
+
+	waiting := NewBlockingQueue[int](0)
+	done := make(chan error)
+	go func() {
+		_, err := waiting.Take(ctx)
+		done <- err
+	}()
+	waiting.Close()
+	assert.ErrorIs(<-done, ErrClosed)
+
+	bq.Close()
+	bq.Close()
+	assert.True(bq.Closed())
+	assert.ErrorIs(bq.Put(ctx, 2), ErrClosed)
+	assert.ErrorIs(bq.TryPut(2), ErrClosed)
+	value, err := bq.Take(ctx)
+	assert.NoError(err)
+	assert.Equal(1, value)
+	_, err = bq.Take(ctx)
+	assert.ErrorIs(err, ErrClosed)
+}
+
+func TestBlockingQueueProducersConsumers(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	bq := NewBlockingQueue[int](4)
+	var producers, consumers sync.WaitGroup
+	for p := 0; p < 4; p++ {
+		producers.Add(1)
+		go func() {
+			defer producers.Done()
+			for i := 0; i < 250; i++ {
+				assert.NoError(bq.Put(ctx, i))
+			}
+		}()
+	}
+	counts := make([]int, 4)
+	for c := 0; c < 4; c++ {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				if _, err := bq.Take(ctx); err != nil {
+					assert.ErrorIs(err, ErrClosed)
+					return
+				}
+				counts[c]++
+			}
+		}()
+	}
+	producers.Wait()
+	bq.Close()
+	consumers.Wait()
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	assert.Equal(1000, total)
+}
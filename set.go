@@ -1,10 +1,16 @@
 package q
 
-import "fmt"
+import (
+	"fmt"
+	"iter"
+)
 
 // Set is a generic set data structure that stores unique elements of type T.
 type Set[T comparable] struct {
-	data map[T]struct{}
+	data      map[T]struct{}
+	order     func(a, b T) bool
+	tx        *txLog
+	observers observerList[T]
 }
 
 // NewSet creates a new Set and returns a pointer to it.
@@ -19,14 +25,36 @@ func NewSet[T comparable](elements ...T) *Set[T] {
 // Time complexity: O(n), where n is the number of elements being added.
 func (s *Set[T]) Add(elements ...T) {
 	for _, element := range elements {
+		if s.tx == nil && len(s.observers) == 0 {
+			s.data[element] = struct{}{}
+			continue
+		}
+		if s.Contains(element) {
+			continue
+		}
 		s.data[element] = struct{}{}
+		if s.tx != nil {
+			s.tx.record(func() { s.Remove(element) })
+		}
+		s.observers.emit(Event[T]{Kind: EventInserted, Value: element, Index: -1})
 	}
 }
 
 // Remove removes an element from the set.
 // Time complexity: O(1).
 func (s *Set[T]) Remove(element T) {
+	if s.tx == nil && len(s.observers) == 0 {
+		delete(s.data, element)
+		return
+	}
+	if !s.Contains(element) {
+		return
+	}
 	delete(s.data, element)
+	if s.tx != nil {
+		s.tx.record(func() { s.Add(element) })
+	}
+	s.observers.emit(Event[T]{Kind: EventRemoved, Value: element, Index: -1})
 }
 
 // Contains checks if an element is present in the set.
@@ -45,7 +73,17 @@ func (s *Set[T]) Len() int {
 // Clear removes all elements from the set.
 // Time complexity: O(1).
 func (s *Set[T]) Clear() {
+	if s.tx != nil {
+		data := s.data
+		s.tx.record(func() {
+			s.data = data
+			for element := range data {
+				s.observers.emit(Event[T]{Kind: EventInserted, Value: element, Index: -1})
+			}
+		})
+	}
 	s.data = make(map[T]struct{})
+	s.observers.emit(Event[T]{Kind: EventCleared, Index: -1})
 }
 
 // Elements returns a slice containing all the elements in the set.
@@ -58,6 +96,24 @@ func (s *Set[T]) Elements() []T {
 	return elements
 }
 
+// All returns an iterator over the elements in the set, in no particular order.
+// Time complexity: O(n), where n is the number of elements in the set.
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for element := range s.data {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Stream returns a lazy stream over the elements in the set, in no particular order.
+// Time complexity: O(1).
+func (s *Set[T]) Stream() *Stream[T] {
+	return NewStream(s.All())
+}
+
 // Union returns a new set that is the union of the current set and another set.
 // Time complexity: O(n), where n is the total number of elements in both sets.
 func (s *Set[T]) Union(other *Set[T]) *Set[T] {
@@ -95,6 +151,8 @@ func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
 	return result
 }
 
+// String returns a string representation of the set, sorted if its elements are of an ordered type.
+// Time complexity: O(n log n), where n is the number of elements in the set.
 func (s *Set[T]) String() string {
-	return fmt.Sprintf("%v", s.Elements())
+	return fmt.Sprintf("%v", s)
 }
@@ -145,3 +145,16 @@ func ExampleSet_Union() {
 	fmt.Println(sorted)
 	// Output: [1 2 3 4 5]
 }
+
+func TestSetAll(t *testing.T) {
+	assert := assert.New(t)
+	set := NewSet(1, 2, 3)
+	assert.ElementsMatch([]int{1, 2, 3}, slices.Collect(set.All()))
+
+	count := 0
+	for range set.All() {
+		count++
+		break
+	}
+	assert.Equal(1, count)
+}
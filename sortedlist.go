@@ -0,0 +1,221 @@
+package q
+
+import (
+	"fmt"
+	"iter"
+	"math/rand/v2"
+)
+
+// sortedListMaxLevel is the maximum number of levels in a SortedList, enough for 4^32 elements.
+const sortedListMaxLevel = 32
+
+// SortedList is a generic collection that keeps its elements sorted, implemented as an indexable skip list.
+// Equal elements are kept in insertion order.
+type SortedList[M any] struct {
+	less   func(a, b M) bool
+	head   *skipNode[M]
+	level  int
+	length int
+}
+
+// skipNode represents a node in the skip list. span[i] is the number of elements between the node and next[i].
+type skipNode[M any] struct {
+	value M
+	next  []*skipNode[M]
+	span  []int
+}
+
+// NewSortedList creates a new SortedList with the specified less function and initializes it with the given elements.
+// Time complexity: O(n log n), where n is the number of elements.
+func NewSortedList[M any](less func(a, b M) bool, elements ...M) *SortedList[M] {
+	s := &SortedList[M]{
+		less:  less,
+		head:  newSkipNode[M](sortedListMaxLevel),
+		level: 1,
+	}
+	s.Insert(elements...)
+	return s
+}
+
+// newSkipNode creates a node with the given number of levels.
+// Time complexity: O(level).
+func newSkipNode[M any](level int) *skipNode[M] {
+	return &skipNode[M]{
+		next: make([]*skipNode[M], level),
+		span: make([]int, level),
+	}
+}
+
+// Insert adds one or more values to the sorted list.
+// Time complexity: O(log n) for each value, where n is the number of elements in the sorted list.
+func (s *SortedList[M]) Insert(values ...M) {
+	var update [sortedListMaxLevel]*skipNode[M]
+	var rank [sortedListMaxLevel]int
+	for _, value := range values {
+		x := s.head
+		for i := s.level - 1; i >= 0; i-- {
+			if i < s.level-1 {
+				rank[i] = rank[i+1]
+			} else {
+				rank[i] = 0
+			}
+			for x.next[i] != nil && !s.less(value, x.next[i].value) {
+				rank[i] += x.span[i]
+				x = x.next[i]
+			}
+			update[i] = x
+		}
+		level := randomLevel()
+		for i := s.level; i < level; i++ {
+			rank[i] = 0
+			update[i] = s.head
+			s.head.span[i] = s.length
+		}
+		s.level = max(s.level, level)
+
+		node := newSkipNode[M](level)
+		node.value = value
+		for i := 0; i < level; i++ {
+			node.next[i] = update[i].next[i]
+			update[i].next[i] = node
+			node.span[i] = update[i].span[i] - (rank[0] - rank[i])
+			update[i].span[i] = rank[0] - rank[i] + 1
+		}
+		for i := level; i < s.level; i++ {
+			update[i].span[i]++
+		}
+		s.length++
+	}
+}
+
+// Delete removes the first element equal to the value. It returns false if the value is not present.
+// Time complexity: O(log n), where n is the number of elements in the sorted list.
+func (s *SortedList[M]) Delete(value M) bool {
+	var update [sortedListMaxLevel]*skipNode[M]
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && s.less(x.next[i].value, value) {
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+	node := x.next[0]
+	if node == nil || s.less(value, node.value) {
+		return false
+	}
+	for i := 0; i < s.level; i++ {
+		if update[i].next[i] == node {
+			update[i].span[i] += node.span[i] - 1
+			update[i].next[i] = node.next[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.length--
+	return true
+}
+
+// Contains checks if an element equal to the value is present in the sorted list.
+// Time complexity: O(log n), where n is the number of elements in the sorted list.
+func (s *SortedList[M]) Contains(value M) bool {
+	node, _ := s.lowerBound(value)
+	return node != nil && !s.less(value, node.value)
+}
+
+// Rank returns the number of elements in the sorted list that are less than the value.
+// Time complexity: O(log n), where n is the number of elements in the sorted list.
+func (s *SortedList[M]) Rank(value M) int {
+	_, rank := s.lowerBound(value)
+	return rank
+}
+
+// At returns the value at index i in sorted order. Negative indices count back from the end of the sorted list.
+// It returns ErrOutOfRange if the index is outside the sorted list.
+// Time complexity: O(log n), where n is the number of elements in the sorted list.
+func (s *SortedList[M]) At(i int) (M, error) {
+	i, err := resolveIndex(i, s.length, false)
+	if err != nil {
+		var m M
+		return m, err
+	}
+	x := s.head
+	traversed := 0
+	for level := s.level - 1; level >= 0; level-- {
+		for x.next[level] != nil && traversed+x.span[level] <= i+1 {
+			traversed += x.span[level]
+			x = x.next[level]
+		}
+	}
+	return x.value, nil
+}
+
+// Range returns a slice containing the elements that are not less than lo and less than hi, in sorted order.
+// Time complexity: O(log n + k), where n is the number of elements in the sorted list and k is the number of results.
+func (s *SortedList[M]) Range(lo, hi M) []M {
+	var result []M
+	for node, _ := s.lowerBound(lo); node != nil && s.less(node.value, hi); node = node.next[0] {
+		result = append(result, node.value)
+	}
+	return result
+}
+
+// Len returns the number of elements in the sorted list.
+// Time complexity: O(1).
+func (s *SortedList[M]) Len() int {
+	return s.length
+}
+
+// Values returns an iterator over the elements in the sorted list, in sorted order.
+// Time complexity: O(n), where n is the number of elements in the sorted list.
+func (s *SortedList[M]) Values() iter.Seq[M] {
+	return func(yield func(M) bool) {
+		for node := s.head.next[0]; node != nil; node = node.next[0] {
+			if !yield(node.value) {
+				return
+			}
+		}
+	}
+}
+
+// Elements returns a slice containing all the elements in the sorted list, in sorted order.
+// Time complexity: O(n), where n is the number of elements in the sorted list.
+func (s *SortedList[M]) Elements() []M {
+	result := make([]M, 0, s.length)
+	for v := range s.Values() {
+		result = append(result, v)
+	}
+	return result
+}
+
+// String returns a string representation of the sorted list.
+// Time complexity: O(n), where n is the number of elements in the sorted list.
+func (s *SortedList[M]) String() string {
+	return fmt.Sprintf("%v", s.Elements())
+}
+
+// lowerBound returns the first node that is not less than the value, and the number of elements before it.
+// Time complexity: O(log n), where n is the number of elements in the sorted list.
+func (s *SortedList[M]) lowerBound(value M) (*skipNode[M], int) {
+	x := s.head
+	rank := 0
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && s.less(x.next[i].value, value) {
+			rank += x.span[i]
+			x = x.next[i]
+		}
+	}
+	return x.next[0], rank
+}
+
+// randomLevel returns a random level for a new node, where each additional level has a one in four chance.
+// Time complexity: O(1) expected.
+func randomLevel() int {
+	level := 1
+	for level < sortedListMaxLevel && rand.IntN(4) == 0 {
+		level++
+	}
+	return level
+}
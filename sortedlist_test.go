@@ -0,0 +1,97 @@
+package q
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedList(t *testing.T) {
+	assert := assert.New(t)
+	s := NewSortedList(Less[int], 5, 1, 3)
+	s.Insert(4, 2, 3)
+	assert.Equal([]int{1, 2, 3, 3, 4, 5}, s.Elements())
+	assert.Equal(6, s.Len())
+	assert.Equal("[1 2 3 3 4 5]", s.String())
+
+	assert.True(s.Contains(3))
+	assert.False(s.Contains(6))
+	assert.Equal(2, s.Rank(3))
+	assert.Equal(6, s.Rank(10))
+	assert.Equal(0, s.Rank(0))
+
+	assert.Equal([]int{2, 3, 3}, s.Range(2, 4))
+	assert.Empty(s.Range(6, 10))
+
+	assert.True(s.Delete(3))
+	assert.True(s.Delete(1))
+	assert.False(s.Delete(1))
+	assert.Equal([]int{2, 3, 4, 5}, s.Elements())
+
+	value, err := s.At(-1)
+	assert.NoError(err)
+	assert.Equal(5, value)
+	_, err = s.At(4)
+	assert.ErrorIs(err, ErrOutOfRange)
+}
+
+func TestSortedListRandom(t *testing.T) {
+	assert := assert.New(t)
+	s := NewSortedList(Less[int])
+	var expected []int
+	for i := 0; i < 5000; i++ {
+		value := rand.Intn(1000)
+		if rand.Intn(3) == 0 {
+			index, found := slices.BinarySearch(expected, value)
+			assert.Equal(found, s.Delete(value))
+			if found {
+				expected = slices.Delete(expected, index, index+1)
+			}
+		} else {
+			s.Insert(value)
+			index, _ := slices.BinarySearch(expected, value+1)
+			expected = slices.Insert(expected, index, value)
+		}
+	}
+	assert.Equal(len(expected), s.Len())
+	assert.Equal(expected, s.Elements())
+	for i := 0; i < len(expected); i += 37 {
+		value, err := s.At(i)
+		assert.NoError(err)
+		assert.Equal(expected[i], value)
+		rank, _ := slices.BinarySearch(expected, expected[i])
+		assert.Equal(rank, s.Rank(expected[i]))
+	}
+}
+
+func TestSortedListStable(t *testing.T) {
+	assert := assert.New(t)
+	type pair struct{ key, order int }
+	s := NewSortedList(func(a, b pair) bool {
+		return a.key < b.key
+	})
+	for i := 0; i < 100; i++ {
+		s.Insert(pair{key: i % 3, order: i})
+	}
+	previous := pair{key: -1}
+	for v := range s.Values() {
+		assert.True(previous.key < v.key || previous.order < v.order)
+		previous = v
+	}
+}
+
+// ExampleSortedList demonstrates how to keep values sorted as they are inserted.
+func ExampleSortedList() {
+	s := NewSortedList(func(a, b string) bool {
+		return a < b
+	}, "pear", "apple")
+	s.Insert("fig")
+	fmt.Println(s)
+	fmt.Println(s.Rank("orange"))
+	// Output:
+	// [apple fig pear]
+	// 2
+}
@@ -0,0 +1,199 @@
+package q
+
+import "iter"
+
+// Stream is a lazy sequence of values. Intermediate operations such as Filter and Take return new streams
+// without evaluating anything; the values are only produced when a terminal operation such as ToList is called.
+type Stream[M any] struct {
+	seq iter.Seq[M]
+}
+
+// NewStream creates a new Stream over an iterator.
+// Time complexity: O(1).
+func NewStream[M any](seq iter.Seq[M]) *Stream[M] {
+	return &Stream[M]{seq: seq}
+}
+
+// StreamSlice creates a new Stream over the values of a slice.
+// Time complexity: O(1).
+func StreamSlice[M any](values []M) *Stream[M] {
+	return NewStream(func(yield func(M) bool) {
+		for _, v := range values {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// StreamChan creates a new Stream over the values received from a channel until it is closed.
+// The stream can only be consumed once.
+// Time complexity: O(1).
+func StreamChan[M any](ch <-chan M) *Stream[M] {
+	return NewStream(func(yield func(M) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// Seq returns an iterator over the values of the stream.
+// Time complexity: O(1).
+func (s *Stream[M]) Seq() iter.Seq[M] {
+	return s.seq
+}
+
+// Filter returns a stream of the values that satisfy the callback function.
+// Time complexity: O(1).
+func (s *Stream[M]) Filter(callback func(M) bool) *Stream[M] {
+	return NewStream(func(yield func(M) bool) {
+		for v := range s.seq {
+			if callback(v) && !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// Take returns a stream of at most the first n values.
+// Time complexity: O(1).
+func (s *Stream[M]) Take(n int) *Stream[M] {
+	return NewStream(func(yield func(M) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for v := range s.seq {
+			if !yield(v) {
+				return
+			}
+			i++
+			if i == n {
+				return
+			}
+		}
+	})
+}
+
+// Skip returns a stream without the first n values.
+// Time complexity: O(1).
+func (s *Stream[M]) Skip(n int) *Stream[M] {
+	return NewStream(func(yield func(M) bool) {
+		i := 0
+		for v := range s.seq {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// Sorted returns a stream of the values sorted using the provided less function.
+// The sort is stable. All the values are buffered once the stream is consumed.
+// Time complexity: O(1).
+func (s *Stream[M]) Sorted(less func(M, M) bool) *Stream[M] {
+	return NewStream(func(yield func(M) bool) {
+		sorted := s.ToList()
+		sorted.SortInPlace(less)
+		for v := range sorted.Values() {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// ToList consumes the stream and returns a new list with its values.
+// Time complexity: O(n), where n is the number of values in the stream.
+func (s *Stream[M]) ToList() *List[M] {
+	result := NewList[M]()
+	for v := range s.seq {
+		result.PushRight(v)
+	}
+	return result
+}
+
+// First consumes the stream up to its first value and returns it. It returns false if the stream is empty.
+// Time complexity: O(1), plus the cost of producing the first value.
+func (s *Stream[M]) First() (M, bool) {
+	for v := range s.seq {
+		return v, true
+	}
+	var m M
+	return m, false
+}
+
+// Count consumes the stream and returns the number of values.
+// Time complexity: O(n), where n is the number of values in the stream.
+func (s *Stream[M]) Count() int {
+	count := 0
+	for range s.seq {
+		count++
+	}
+	return count
+}
+
+// MapStream returns a stream of the results of applying a callback function to each value.
+// Time complexity: O(1).
+func MapStream[M any, N any](s *Stream[M], callback func(M) N) *Stream[N] {
+	return NewStream(func(yield func(N) bool) {
+		for v := range s.seq {
+			if !yield(callback(v)) {
+				return
+			}
+		}
+	})
+}
+
+// DistinctStream returns a stream of the first occurrence of each value.
+// Time complexity: O(1).
+func DistinctStream[M comparable](s *Stream[M]) *Stream[M] {
+	return NewStream(func(yield func(M) bool) {
+		seen := NewSet[M]()
+		for v := range s.seq {
+			if seen.Contains(v) {
+				continue
+			}
+			seen.Add(v)
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// ReduceStream consumes the stream and returns a single accumulated value.
+// Time complexity: O(n), where n is the number of values in the stream.
+func ReduceStream[M any, N any](s *Stream[M], callback func(N, M) N, initial N) N {
+	acc := initial
+	for v := range s.seq {
+		acc = callback(acc, v)
+	}
+	return acc
+}
+
+// CollectSet consumes the stream and returns a new set with its values.
+// Time complexity: O(n), where n is the number of values in the stream.
+func CollectSet[M comparable](s *Stream[M]) *Set[M] {
+	result := NewSet[M]()
+	for v := range s.seq {
+		result.Add(v)
+	}
+	return result
+}
+
+// CollectCounter consumes the stream and returns a new counter with its values.
+// Time complexity: O(n), where n is the number of values in the stream.
+func CollectCounter[M comparable](s *Stream[M]) *Counter[M] {
+	result := NewCounter[M]()
+	for v := range s.seq {
+		result.Add(v)
+	}
+	return result
+}
@@ -0,0 +1,79 @@
+package q
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamLazy(t *testing.T) {
+	assert := assert.New(t)
+	calls := 0
+	s := NewList(1, 2, 3, 4, 5, 6).Stream().Filter(func(value int) bool {
+		calls++
+		return value%2 == 0
+	}).Take(2)
+	assert.Equal(0, calls)
+	assert.Equal([]int{2, 4}, s.ToList().Elements())
+	assert.Equal(4, calls)
+}
+
+func TestStreamOperations(t *testing.T) {
+	assert := assert.New(t)
+	s := StreamSlice([]int{5, 3, 5, 1, 4, 3})
+	assert.Equal([]int{5, 3, 1, 4}, DistinctStream(s).ToList().Elements())
+	assert.Equal([]int{1, 3, 3, 4, 5, 5}, s.Sorted(Less[int]).ToList().Elements())
+	assert.Equal([]int{5, 1, 4, 3}, s.Skip(2).ToList().Elements())
+	assert.Equal(0, s.Take(0).Count())
+	assert.Equal(6, s.Count())
+
+	doubled := MapStream(s, func(value int) string {
+		return fmt.Sprint(value * 2)
+	})
+	assert.Equal([]string{"10", "6"}, slices.Collect(doubled.Take(2).Seq()))
+
+	sum := ReduceStream(s, func(acc, value int) int {
+		return acc + value
+	}, 0)
+	assert.Equal(21, sum)
+
+	first, ok := s.Skip(1).First()
+	assert.True(ok)
+	assert.Equal(3, first)
+	_, ok = s.Skip(6).First()
+	assert.False(ok)
+}
+
+func TestStreamSources(t *testing.T) {
+	assert := assert.New(t)
+	counter := NewCounter("a", "b", "a")
+	assert.True(counter.Equal(CollectCounter(counter.Stream())))
+
+	set := NewSet(1, 2, 3)
+	assert.ElementsMatch([]int{1, 2, 3}, CollectSet(set.Stream()).Elements())
+
+	heap := NewHeap(Less[int], 3, 1, 2)
+	assert.Equal([]int{1, 2, 3}, heap.Stream().ToList().Elements())
+	assert.Equal([]int{1, 2}, NewDeque(1, 2).Stream().ToList().Elements())
+
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	assert.Equal([]int{1, 2, 3}, StreamChan(ch).ToList().Elements())
+}
+
+// ExampleStream demonstrates how to build a lazy pipeline over a list.
+func ExampleStream() {
+	list := NewList(1, 2, 3, 4, 5, 6, 7, 8)
+	squares := MapStream(list.Stream().Filter(func(value int) bool {
+		return value%2 == 1
+	}), func(value int) int {
+		return value * value
+	})
+	fmt.Println(squares.Take(3).ToList())
+	// Output: [1 9 25]
+}
@@ -0,0 +1,1145 @@
+package q
+
+import (
+	"iter"
+	"slices"
+	"sync"
+)
+
+// SyncList is a List that is safe for concurrent use by multiple goroutines. It has the methods of List except
+// those that expose node handles (Front, Back, InsertBefore, InsertAfter, RemoveNode, MoveToFront, MoveToBack and
+// MoveAfter), Begin and Observe, whose use spans several calls, and Format; use Do for operations that need them.
+// Iterators yield from a snapshot taken when they are created. A SyncList must be created with NewSyncList; the zero
+// value is only usable as the target of UnmarshalJSON, UnmarshalBinary or GobDecode.
+type SyncList[M any] struct {
+	mu   sync.RWMutex
+	list *List[M]
+}
+
+// NewSyncList creates a new SyncList and initializes it with the given elements.
+// Time complexity: O(n), where n is the number of elements.
+func NewSyncList[M any](elements ...M) *SyncList[M] {
+	return &SyncList[M]{list: NewList(elements...)}
+}
+
+// Do calls the callback with the underlying list while holding the write lock, so that a sequence of operations
+// is atomic. The list must not be retained after the callback returns.
+func (s *SyncList[M]) Do(callback func(*List[M])) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	callback(s.list)
+}
+
+// Snapshot returns an unsynchronized copy of the list.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) Snapshot() *List[M] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.Copy()
+}
+
+// Swap replaces the underlying list and returns the previous one, which is no longer synchronized.
+// It panics if list is nil.
+// Time complexity: O(1).
+func (s *SyncList[M]) Swap(list *List[M]) *List[M] {
+	if list == nil {
+		panic("q: cannot swap in a nil List")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := s.list
+	s.list = list
+	return previous
+}
+
+// Push adds values to the list.
+// Time complexity: O(n), where n is the number of values.
+func (s *SyncList[M]) Push(values ...M) {
+	s.PushRight(values...)
+}
+
+// Pop removes and returns the last value from the list.
+// Time complexity: O(1).
+func (s *SyncList[M]) Pop() M {
+	return s.PopRight()
+}
+
+// TryPop removes and returns the last value from the list. It returns false if the list is empty.
+// Time complexity: O(1).
+func (s *SyncList[M]) TryPop() (M, bool) {
+	return s.TryPopRight()
+}
+
+// PushRight adds values to the end of the list.
+// Time complexity: O(n), where n is the number of values.
+func (s *SyncList[M]) PushRight(values ...M) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.list.PushRight(values...)
+}
+
+// PushLeft adds values to the beginning of the list.
+// Time complexity: O(n), where n is the number of values.
+func (s *SyncList[M]) PushLeft(values ...M) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.list.PushLeft(values...)
+}
+
+// PopRight removes and returns the last value from the list.
+// Time complexity: O(1).
+func (s *SyncList[M]) PopRight() M {
+	value, _ := s.TryPopRight()
+	return value
+}
+
+// TryPopRight removes and returns the last value from the list. It returns false if the list is empty.
+// Time complexity: O(1).
+func (s *SyncList[M]) TryPopRight() (M, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list.TryPopRight()
+}
+
+// PopLeft removes and returns the first value from the list.
+// Time complexity: O(1).
+func (s *SyncList[M]) PopLeft() M {
+	value, _ := s.TryPopLeft()
+	return value
+}
+
+// TryPopLeft removes and returns the first value from the list. It returns false if the list is empty.
+// Time complexity: O(1).
+func (s *SyncList[M]) TryPopLeft() (M, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list.TryPopLeft()
+}
+
+// PopLeftIf removes and returns the first value from the list if it satisfies the callback function.
+// It returns false if the list is empty or the value does not satisfy the callback.
+// Time complexity: O(1).
+func (s *SyncList[M]) PopLeftIf(callback func(M) bool) (M, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.list.TryPeekLeft()
+	if !ok || !callback(value) {
+		var m M
+		return m, false
+	}
+	return s.list.TryPopLeft()
+}
+
+// PeekLeft returns the first value in the list without removing it.
+// Time complexity: O(1).
+func (s *SyncList[M]) PeekLeft() M {
+	value, _ := s.TryPeekLeft()
+	return value
+}
+
+// TryPeekLeft returns the first value in the list without removing it. It returns false if the list is empty.
+// Time complexity: O(1).
+func (s *SyncList[M]) TryPeekLeft() (M, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.TryPeekLeft()
+}
+
+// PeekRight returns the last value in the list without removing it.
+// Time complexity: O(1).
+func (s *SyncList[M]) PeekRight() M {
+	value, _ := s.TryPeekRight()
+	return value
+}
+
+// TryPeekRight returns the last value in the list without removing it. It returns false if the list is empty.
+// Time complexity: O(1).
+func (s *SyncList[M]) TryPeekRight() (M, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.TryPeekRight()
+}
+
+// At returns the value at index i. Negative indices count back from the end of the list.
+// It returns ErrOutOfRange if the index is outside the list.
+// Time complexity: O(min(i, n-i)), where n is the number of elements in the list.
+func (s *SyncList[M]) At(i int) (M, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.At(i)
+}
+
+// SetAt replaces the value at index i. Negative indices count back from the end of the list.
+// It returns ErrOutOfRange if the index is outside the list.
+// Time complexity: O(min(i, n-i)), where n is the number of elements in the list.
+func (s *SyncList[M]) SetAt(i int, value M) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list.SetAt(i, value)
+}
+
+// InsertAt inserts values before index i. Negative indices count back from the end of the list.
+// It returns ErrOutOfRange if the index is outside the list.
+// Time complexity: O(min(i, n-i) + m), where n is the number of elements in the list and m is the number of values.
+func (s *SyncList[M]) InsertAt(i int, values ...M) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list.InsertAt(i, values...)
+}
+
+// RemoveAt removes and returns the value at index i. Negative indices count back from the end of the list.
+// It returns ErrOutOfRange if the index is outside the list.
+// Time complexity: O(min(i, n-i)), where n is the number of elements in the list.
+func (s *SyncList[M]) RemoveAt(i int) (M, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list.RemoveAt(i)
+}
+
+// RemoveFunc removes every value that satisfies the callback function and returns how many were removed.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) RemoveFunc(callback func(int, M) bool) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list.RemoveFunc(callback)
+}
+
+// Retain removes every value that does not satisfy the callback function and returns how many were removed.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) Retain(callback func(int, M) bool) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list.Retain(callback)
+}
+
+// Clear removes all elements from the list.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.list.Clear()
+}
+
+// Reverse reverses the order of the list.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) Reverse() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.list.Reverse()
+}
+
+// Rotate rotates the list k steps to the right, or -k steps to the left if k is negative.
+// Time complexity: O(min(k, n-k)), where n is the number of elements in the list.
+func (s *SyncList[M]) Rotate(k int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.list.Rotate(k)
+}
+
+// SortInPlace sorts the list using the provided less function.
+// Time complexity: O(n log n), where n is the number of elements in the list.
+func (s *SyncList[M]) SortInPlace(less func(M, M) bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.list.SortInPlace(less)
+}
+
+// Sort returns a new, unsynchronized list with the values of the list sorted using the provided less function.
+// Time complexity: O(n log n), where n is the number of elements in the list.
+func (s *SyncList[M]) Sort(less func(M, M) bool) *List[M] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.Sort(less)
+}
+
+// IsSorted returns true if the list is sorted according to the provided less function, false otherwise.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) IsSorted(less func(M, M) bool) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.IsSorted(less)
+}
+
+// Each applies a callback function to each value in the list while holding the read lock.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) Each(callback func(int, M)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.list.Each(callback)
+}
+
+// Find returns the first value in the list that satisfies the callback function.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) Find(callback func(int, M) bool) M {
+	value, _ := s.FindOk(callback)
+	return value
+}
+
+// FindOk returns the first value in the list that satisfies the callback function. It returns false if no value does.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) FindOk(callback func(int, M) bool) (M, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.FindOk(callback)
+}
+
+// Filter returns a new, unsynchronized list containing only the values that satisfy the callback function.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) Filter(callback func(int, M) bool) *List[M] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.Filter(callback)
+}
+
+// All returns true if all values in the list satisfy the callback function, false otherwise.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) All(callback func(int, M) bool) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.All(callback)
+}
+
+// Any returns true if at least one value in the list satisfies the callback function, false otherwise.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) Any(callback func(int, M) bool) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.Any(callback)
+}
+
+// Slice returns a new, unsynchronized list containing the elements from the start index to the stop index.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) Slice(start, stop int) *List[M] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.Slice(start, stop)
+}
+
+// Values returns an iterator over a snapshot of the values in the list, from left to right.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) Values() iter.Seq[M] {
+	return slices.Values(s.Elements())
+}
+
+// Elements returns a slice containing all the values in the list.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) Elements() []M {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.Elements()
+}
+
+// Len returns the length of the list.
+// Time complexity: O(1).
+func (s *SyncList[M]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.Len()
+}
+
+// Len64 returns the length of the list as an int64.
+// Time complexity: O(1).
+func (s *SyncList[M]) Len64() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.Len64()
+}
+
+// String returns a string representation of the list.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) String() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.String()
+}
+
+// PopErr removes and returns the last value from the list. It returns ErrEmpty if the list is empty.
+// Time complexity: O(1).
+func (s *SyncList[M]) PopErr() (M, error) {
+	return s.PopRightErr()
+}
+
+// PopRightErr removes and returns the last value from the list. It returns ErrEmpty if the list is empty.
+// Time complexity: O(1).
+func (s *SyncList[M]) PopRightErr() (M, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list.PopRightErr()
+}
+
+// PopLeftErr removes and returns the first value from the list. It returns ErrEmpty if the list is empty.
+// Time complexity: O(1).
+func (s *SyncList[M]) PopLeftErr() (M, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list.PopLeftErr()
+}
+
+// PeekLeftErr returns the first value in the list without removing it. It returns ErrEmpty if the list is empty.
+// Time complexity: O(1).
+func (s *SyncList[M]) PeekLeftErr() (M, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.PeekLeftErr()
+}
+
+// PeekRightErr returns the last value in the list without removing it. It returns ErrEmpty if the list is empty.
+// Time complexity: O(1).
+func (s *SyncList[M]) PeekRightErr() (M, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.PeekRightErr()
+}
+
+// Extend moves the elements of other, unsynchronized lists to the end of the list, leaving them empty.
+// Time complexity: O(m), where m is the total number of elements in the other lists.
+func (s *SyncList[M]) Extend(lists ...*List[M]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.list.Extend(lists...)
+}
+
+// Splice moves the elements of another, unsynchronized list into the list before index i, leaving it empty.
+// It returns ErrOutOfRange if the index is outside the list and ErrSameList if other is the underlying list.
+// Time complexity: O(min(i, n-i) + m), where n is the number of elements in the list and m is the number of elements in other.
+func (s *SyncList[M]) Splice(i int, other *List[M]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list.Splice(i, other)
+}
+
+// SplitAt cuts the list before index i and returns the elements from i onwards as a new, unsynchronized list.
+// It returns ErrOutOfRange if the index is outside the list.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) SplitAt(i int) (*List[M], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list.SplitAt(i)
+}
+
+// MaxLen returns the maximum length of the list, or 0 if the list is unbounded.
+// Time complexity: O(1).
+func (s *SyncList[M]) MaxLen() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.MaxLen()
+}
+
+// OnEvict registers a callback that receives each value evicted from a bounded list. A nil callback removes it.
+// The callback runs while the lock is held and must not use the SyncList.
+// Time complexity: O(1).
+func (s *SyncList[M]) OnEvict(callback func(M)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.list.OnEvict(callback)
+}
+
+// Copy returns a new, unsynchronized list with the same values and maximum length as the list.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) Copy() *List[M] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.Copy()
+}
+
+// ToPList returns a new persistent list with the values of the list.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) ToPList() PList[M] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.ToPList()
+}
+
+// Validate checks the internal invariants of the list, like List.Validate.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) Validate() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.Validate()
+}
+
+// Iter returns an iterator over the index and value of each element in a snapshot of the list, from left to right.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) Iter() iter.Seq2[int, M] {
+	return slices.All(s.Elements())
+}
+
+// Backward returns an iterator over the index and value of each element in a snapshot of the list, from right to left.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) Backward() iter.Seq2[int, M] {
+	return slices.Backward(s.Elements())
+}
+
+// Stream returns a lazy stream over a snapshot of the values in the list, from left to right.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) Stream() *Stream[M] {
+	return StreamSlice(s.Elements())
+}
+
+// MarshalJSON encodes the list as JSON, like List.MarshalJSON.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) MarshalJSON() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.MarshalJSON()
+}
+
+// UnmarshalJSON replaces the contents of the list with JSON data, like List.UnmarshalJSON.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) UnmarshalJSON(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.list == nil {
+		s.list = &List[M]{}
+	}
+	return s.list.UnmarshalJSON(data)
+}
+
+// MarshalBinary encodes the list in the binary format of List.MarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) MarshalBinary() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list.MarshalBinary()
+}
+
+// UnmarshalBinary replaces the contents of the list with data written by MarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) UnmarshalBinary(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.list == nil {
+		s.list = &List[M]{}
+	}
+	return s.list.UnmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder using MarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using UnmarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (s *SyncList[M]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+// SyncSet is a Set that is safe for concurrent use by multiple goroutines. It has the methods of Set except Begin,
+// Observe and Format; use Do for operations that need them. Iterators yield from a snapshot taken when they are created.
+// A SyncSet must be created with NewSyncSet; the zero value is only usable as the target of UnmarshalJSON,
+// UnmarshalBinary or GobDecode.
+type SyncSet[T comparable] struct {
+	mu  sync.RWMutex
+	set *Set[T]
+}
+
+// NewSyncSet creates a new SyncSet and initializes it with the given elements.
+// Time complexity: O(n), where n is the number of elements.
+func NewSyncSet[T comparable](elements ...T) *SyncSet[T] {
+	return &SyncSet[T]{set: NewSet(elements...)}
+}
+
+// Do calls the callback with the underlying set while holding the write lock, so that a sequence of operations
+// is atomic. The set must not be retained after the callback returns.
+func (s *SyncSet[T]) Do(callback func(*Set[T])) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	callback(s.set)
+}
+
+// Snapshot returns an unsynchronized copy of the set.
+// Time complexity: O(n), where n is the number of elements in the set.
+func (s *SyncSet[T]) Snapshot() *Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return NewSet(s.set.Elements()...)
+}
+
+// Swap replaces the underlying set and returns the previous one, which is no longer synchronized.
+// It panics if set is nil.
+// Time complexity: O(1).
+func (s *SyncSet[T]) Swap(set *Set[T]) *Set[T] {
+	if set == nil {
+		panic("q: cannot swap in a nil Set")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := s.set
+	s.set = set
+	return previous
+}
+
+// Add adds one or more elements to the set.
+// Time complexity: O(n), where n is the number of elements being added.
+func (s *SyncSet[T]) Add(elements ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Add(elements...)
+}
+
+// AddIfAbsent adds an element to the set. It returns false if the element was already present.
+// Time complexity: O(1).
+func (s *SyncSet[T]) AddIfAbsent(element T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.set.Contains(element) {
+		return false
+	}
+	s.set.Add(element)
+	return true
+}
+
+// Remove removes an element from the set.
+// Time complexity: O(1).
+func (s *SyncSet[T]) Remove(element T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Remove(element)
+}
+
+// Contains checks if an element is present in the set.
+// Time complexity: O(1).
+func (s *SyncSet[T]) Contains(element T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Contains(element)
+}
+
+// Len returns the number of elements in the set.
+// Time complexity: O(1).
+func (s *SyncSet[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Len()
+}
+
+// Clear removes all elements from the set.
+// Time complexity: O(1).
+func (s *SyncSet[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Clear()
+}
+
+// Elements returns a slice containing all the elements in the set.
+// Time complexity: O(n), where n is the number of elements in the set.
+func (s *SyncSet[T]) Elements() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Elements()
+}
+
+// All returns an iterator over a snapshot of the elements in the set, in no particular order.
+// Time complexity: O(n), where n is the number of elements in the set.
+func (s *SyncSet[T]) All() iter.Seq[T] {
+	return slices.Values(s.Elements())
+}
+
+// Union returns a new, unsynchronized set that is the union of the set and another set.
+// Time complexity: O(n), where n is the total number of elements in both sets.
+func (s *SyncSet[T]) Union(other *Set[T]) *Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Union(other)
+}
+
+// Intersection returns a new, unsynchronized set that is the intersection of the set and another set.
+// Time complexity: O(n), where n is the number of elements in the smaller set.
+func (s *SyncSet[T]) Intersection(other *Set[T]) *Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Intersection(other)
+}
+
+// Difference returns a new, unsynchronized set that contains the elements present in the set but not in another set.
+// Time complexity: O(n), where n is the number of elements in the set.
+func (s *SyncSet[T]) Difference(other *Set[T]) *Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Difference(other)
+}
+
+// String returns a string representation of the set.
+// Time complexity: O(n log n), where n is the number of elements in the set.
+func (s *SyncSet[T]) String() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.String()
+}
+
+// Stream returns a lazy stream over a snapshot of the elements in the set, in no particular order.
+// Time complexity: O(n), where n is the number of elements in the set.
+func (s *SyncSet[T]) Stream() *Stream[T] {
+	return StreamSlice(s.Elements())
+}
+
+// MarshalOrder sets the order in which the set's elements are encoded, like Set.MarshalOrder.
+// Time complexity: O(1).
+func (s *SyncSet[T]) MarshalOrder(less func(a, b T) bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.MarshalOrder(less)
+}
+
+// MarshalJSON encodes the set as JSON, like Set.MarshalJSON.
+// Time complexity: O(n log n) with an order set by MarshalOrder and O(n) otherwise, where n is the number of
+// elements in the set.
+func (s *SyncSet[T]) MarshalJSON() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.MarshalJSON()
+}
+
+// UnmarshalJSON replaces the contents of the set with JSON data, like Set.UnmarshalJSON.
+// Time complexity: O(n), where n is the number of elements in the set.
+func (s *SyncSet[T]) UnmarshalJSON(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.set == nil {
+		s.set = &Set[T]{}
+	}
+	return s.set.UnmarshalJSON(data)
+}
+
+// MarshalBinary encodes the set in the binary format of Set.MarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the set.
+func (s *SyncSet[T]) MarshalBinary() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.MarshalBinary()
+}
+
+// UnmarshalBinary replaces the contents of the set with data written by MarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the set.
+func (s *SyncSet[T]) UnmarshalBinary(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.set == nil {
+		s.set = &Set[T]{}
+	}
+	return s.set.UnmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder using MarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the set.
+func (s *SyncSet[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using UnmarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the set.
+func (s *SyncSet[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+// SyncCounter is a Counter that is safe for concurrent use by multiple goroutines. It has the methods of Counter
+// except Begin, Observe and Format; use Do for operations that need them. Iterators yield from a snapshot taken
+// when they are created. A SyncCounter must be created with NewSyncCounter; the zero value is only usable as the
+// target of UnmarshalJSON, UnmarshalBinary or GobDecode.
+type SyncCounter[T comparable] struct {
+	mu      sync.RWMutex
+	counter *Counter[T]
+}
+
+// NewSyncCounter creates a new SyncCounter and initializes it with the given elements.
+// Time complexity: O(n), where n is the number of elements.
+func NewSyncCounter[T comparable](elements ...T) *SyncCounter[T] {
+	return &SyncCounter[T]{counter: NewCounter(elements...)}
+}
+
+// Do calls the callback with the underlying counter while holding the write lock, so that a sequence of operations
+// is atomic. The counter must not be retained after the callback returns.
+func (s *SyncCounter[T]) Do(callback func(*Counter[T])) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	callback(s.counter)
+}
+
+// Snapshot returns an unsynchronized copy of the counter.
+// Time complexity: O(n), where n is the number of unique elements in the counter.
+func (s *SyncCounter[T]) Snapshot() *Counter[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := NewCounter[T]()
+	for element, count := range s.counter.All() {
+		result.data[element] = count
+	}
+	result.size = s.counter.size
+	return result
+}
+
+// Swap replaces the underlying counter and returns the previous one, which is no longer synchronized.
+// It panics if counter is nil.
+// Time complexity: O(1).
+func (s *SyncCounter[T]) Swap(counter *Counter[T]) *Counter[T] {
+	if counter == nil {
+		panic("q: cannot swap in a nil Counter")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := s.counter
+	s.counter = counter
+	return previous
+}
+
+// Add adds one or more elements to the counter.
+// Time complexity: O(n), where n is the number of elements being added.
+func (s *SyncCounter[T]) Add(elements ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counter.Add(elements...)
+}
+
+// AddIfAbsent adds an element to the counter if it is not already counted. It returns false if it was.
+// Time complexity: O(1).
+func (s *SyncCounter[T]) AddIfAbsent(element T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.counter.Contains(element) {
+		return false
+	}
+	s.counter.Add(element)
+	return true
+}
+
+// Remove removes one occurrence of each element from the counter. If an element is not present it returns false.
+// Time complexity: O(n), where n is the number of elements being removed.
+func (s *SyncCounter[T]) Remove(elements ...T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.counter.Remove(elements...)
+}
+
+// Contains checks if an element is present in the counter.
+// Time complexity: O(1).
+func (s *SyncCounter[T]) Contains(element T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.counter.Contains(element)
+}
+
+// Count returns the number of occurences of an element in the counter.
+// Time complexity: O(1).
+func (s *SyncCounter[T]) Count(element T) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.counter.Count(element)
+}
+
+// Len returns the number of elements in the counter.
+// Time complexity: O(1).
+func (s *SyncCounter[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.counter.Len()
+}
+
+// IsEmpty checks if the counter is empty.
+// Time complexity: O(1).
+func (s *SyncCounter[T]) IsEmpty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.counter.IsEmpty()
+}
+
+// Clear removes all elements from the counter.
+// Time complexity: O(1).
+func (s *SyncCounter[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counter.Clear()
+}
+
+// Elements returns a slice containing all the unique elements in the counter.
+// Time complexity: O(n), where n is the number of unique elements in the counter.
+func (s *SyncCounter[T]) Elements() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.counter.Elements()
+}
+
+// Equal checks if the counter is equal to another counter.
+// Time complexity: O(n), where n is the number of unique elements in the counter.
+func (s *SyncCounter[T]) Equal(other *Counter[T]) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.counter.Equal(other)
+}
+
+// String returns a string representation of the counter.
+// Time complexity: O(n log n), where n is the number of unique elements in the counter.
+func (s *SyncCounter[T]) String() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.counter.String()
+}
+
+// All returns an iterator over a snapshot of the unique elements in the counter and their counts, in no particular order.
+// Time complexity: O(n), where n is the number of unique elements in the counter.
+func (s *SyncCounter[T]) All() iter.Seq2[T, int] {
+	return s.Snapshot().All()
+}
+
+// Values returns an iterator over a snapshot of the unique elements in the counter, in no particular order.
+// Time complexity: O(n), where n is the number of unique elements in the counter.
+func (s *SyncCounter[T]) Values() iter.Seq[T] {
+	return slices.Values(s.Elements())
+}
+
+// Stream returns a lazy stream over a snapshot of the counter, repeating each element as many times as it was counted.
+// Time complexity: O(n), where n is the number of unique elements in the counter.
+func (s *SyncCounter[T]) Stream() *Stream[T] {
+	return s.Snapshot().Stream()
+}
+
+// MarshalJSON encodes the counter as JSON, like Counter.MarshalJSON.
+// Time complexity: O(n), where n is the number of elements in the counter.
+func (s *SyncCounter[T]) MarshalJSON() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.counter.MarshalJSON()
+}
+
+// UnmarshalJSON replaces the contents of the counter with JSON data, like Counter.UnmarshalJSON.
+// Time complexity: O(n), where n is the number of elements in the counter.
+func (s *SyncCounter[T]) UnmarshalJSON(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.counter == nil {
+		s.counter = &Counter[T]{}
+	}
+	return s.counter.UnmarshalJSON(data)
+}
+
+// MarshalBinary encodes the counter in the binary format of Counter.MarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the counter.
+func (s *SyncCounter[T]) MarshalBinary() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.counter.MarshalBinary()
+}
+
+// UnmarshalBinary replaces the contents of the counter with data written by MarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the counter.
+func (s *SyncCounter[T]) UnmarshalBinary(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.counter == nil {
+		s.counter = &Counter[T]{}
+	}
+	return s.counter.UnmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder using MarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the counter.
+func (s *SyncCounter[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using UnmarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the counter.
+func (s *SyncCounter[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+// SyncHeap is a Heap that is safe for concurrent use by multiple goroutines. It has the methods of Heap except
+// Observe and Format; use Do for operations that need them. Iterators yield from a snapshot taken when they are created.
+// A SyncHeap must be created with NewSyncHeap, which supplies its less function.
+type SyncHeap[M any] struct {
+	mu   sync.RWMutex
+	heap *Heap[M]
+}
+
+// NewSyncHeap creates a new SyncHeap with the specified less function and initializes it with the given elements.
+// Time complexity: O(n log n), where n is the number of elements.
+func NewSyncHeap[M any](less func(a, b M) bool, elements ...M) *SyncHeap[M] {
+	return &SyncHeap[M]{heap: NewHeap(less, elements...)}
+}
+
+// Do calls the callback with the underlying heap while holding the write lock, so that a sequence of operations
+// is atomic. The heap must not be retained after the callback returns.
+func (s *SyncHeap[M]) Do(callback func(*Heap[M])) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	callback(s.heap)
+}
+
+// Snapshot returns an unsynchronized copy of the heap.
+// Time complexity: O(n), where n is the number of elements in the heap.
+func (s *SyncHeap[M]) Snapshot() *Heap[M] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &Heap[M]{less: s.heap.less, data: slices.Clone(s.heap.data)}
+}
+
+// Swap replaces the underlying heap and returns the previous one, which is no longer synchronized.
+// It panics if heap is nil.
+// Time complexity: O(1).
+func (s *SyncHeap[M]) Swap(heap *Heap[M]) *Heap[M] {
+	if heap == nil {
+		panic("q: cannot swap in a nil Heap")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := s.heap
+	s.heap = heap
+	return previous
+}
+
+// Push adds one or more values to the heap.
+// Time complexity: O(log n) for each value, where n is the number of elements in the heap.
+func (s *SyncHeap[M]) Push(values ...M) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.heap.Push(values...)
+}
+
+// Pop removes and returns the top element from the heap.
+// Time complexity: O(log n), where n is the number of elements in the heap.
+func (s *SyncHeap[M]) Pop() M {
+	value, _ := s.TryPop()
+	return value
+}
+
+// TryPop removes and returns the top element from the heap. It returns false if the heap is empty.
+// Time complexity: O(log n), where n is the number of elements in the heap.
+func (s *SyncHeap[M]) TryPop() (M, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.heap.TryPop()
+}
+
+// PopIf removes and returns the top element from the heap if it satisfies the callback function.
+// It returns false if the heap is empty or the top element does not satisfy the callback.
+// Time complexity: O(log n), where n is the number of elements in the heap.
+func (s *SyncHeap[M]) PopIf(callback func(M) bool) (M, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.heap.TryTop()
+	if !ok || !callback(value) {
+		var m M
+		return m, false
+	}
+	return s.heap.TryPop()
+}
+
+// Top returns the top element of the heap without removing it.
+// Time complexity: O(1).
+func (s *SyncHeap[M]) Top() M {
+	value, _ := s.TryTop()
+	return value
+}
+
+// TryTop returns the top element of the heap without removing it. It returns false if the heap is empty.
+// Time complexity: O(1).
+func (s *SyncHeap[M]) TryTop() (M, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.heap.TryTop()
+}
+
+// Len returns the number of elements in the heap.
+// Time complexity: O(1).
+func (s *SyncHeap[M]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.heap.Len()
+}
+
+// Empty returns true if the heap is empty, false otherwise.
+// Time complexity: O(1).
+func (s *SyncHeap[M]) Empty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.heap.Empty()
+}
+
+// All returns an iterator over a snapshot of the elements in the heap in priority order.
+// Time complexity: O(n) to take the snapshot, plus O(k log k) to yield the first k elements.
+func (s *SyncHeap[M]) All() iter.Seq[M] {
+	return s.Snapshot().All()
+}
+
+// String returns a string representation of the heap.
+// Time complexity: O(n), where n is the number of elements in the heap.
+func (s *SyncHeap[M]) String() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.heap.String()
+}
+
+// PopErr removes and returns the top element from the heap. It returns ErrEmpty if the heap is empty.
+// Time complexity: O(log n), where n is the number of elements in the heap.
+func (s *SyncHeap[M]) PopErr() (M, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.heap.PopErr()
+}
+
+// TopErr returns the top element of the heap without removing it. It returns ErrEmpty if the heap is empty.
+// Time complexity: O(1).
+func (s *SyncHeap[M]) TopErr() (M, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.heap.TopErr()
+}
+
+// Validate checks the heap property, like Heap.Validate.
+// Time complexity: O(n), where n is the number of elements in the heap.
+func (s *SyncHeap[M]) Validate() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.heap.Validate()
+}
+
+// Stream returns a lazy stream over a snapshot of the elements in the heap in priority order.
+// Time complexity: O(n) to take the snapshot, plus O(k log k) to yield the first k elements.
+func (s *SyncHeap[M]) Stream() *Stream[M] {
+	return s.Snapshot().Stream()
+}
+
+// MarshalJSON encodes the heap as JSON, like Heap.MarshalJSON.
+// Time complexity: O(n), where n is the number of elements in the heap.
+func (s *SyncHeap[M]) MarshalJSON() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.heap.MarshalJSON()
+}
+
+// UnmarshalJSON replaces the contents of the heap with JSON data, like Heap.UnmarshalJSON.
+// Time complexity: O(n), where n is the number of elements in the heap.
+func (s *SyncHeap[M]) UnmarshalJSON(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.heap.UnmarshalJSON(data)
+}
+
+// MarshalBinary encodes the heap in the binary format of Heap.MarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the heap.
+func (s *SyncHeap[M]) MarshalBinary() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.heap.MarshalBinary()
+}
+
+// UnmarshalBinary replaces the contents of the heap with data written by MarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the heap.
+func (s *SyncHeap[M]) UnmarshalBinary(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.heap.UnmarshalBinary(data)
+}
+
+// GobEncode implements gob.GobEncoder using MarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the heap.
+func (s *SyncHeap[M]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using UnmarshalBinary.
+// Time complexity: O(n), where n is the number of elements in the heap.
+func (s *SyncHeap[M]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
@@ -0,0 +1,221 @@
+package q
+
+import (
+	"encoding/json"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncListConcurrent(t *testing.T) {
+	assert := assert.New(t)
+	l := NewSyncList[int]()
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				l.PushRight(i)
+				l.Len()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(8000, l.Len())
+
+	popped := 0
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if _, ok := l.TryPopLeft(); !ok {
+					return
+				}
+				l.Do(func(*List[int]) { popped++ })
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(8000, popped)
+	assert.Equal(0, l.Len())
+}
+
+func TestSyncListCompound(t *testing.T) {
+	assert := assert.New(t)
+	l := NewSyncList(1, 2, 3)
+	_, ok := l.PopLeftIf(func(value int) bool { return value > 1 })
+	assert.False(ok)
+	value, ok := l.PopLeftIf(func(value int) bool { return value == 1 })
+	assert.True(ok)
+	assert.Equal(1, value)
+
+	snapshot := l.Snapshot()
+	snapshot.PushRight(4)
+	assert.Equal([]int{2, 3}, l.Elements())
+	assert.Equal([]int{2, 3}, slices.Collect(l.Values()))
+
+	previous := l.Swap(NewList(9))
+	assert.Equal([]int{2, 3}, previous.Elements())
+	assert.Equal("[9]", l.String())
+	assert.Panics(func() { l.Swap(nil) })
+	assert.Equal("[9]", l.String())
+	assert.Panics(func() { NewSyncSet[int]().Swap(nil) })
+	assert.Panics(func() { NewSyncCounter[int]().Swap(nil) })
+	assert.Panics(func() { NewSyncHeap(Less[int]).Swap(nil) })
+
+	var zero SyncHeap[int]
+	assert.Error(zero.UnmarshalJSON([]byte("[1]")))
+}
+
+func TestSyncSet(t *testing.T) {
+	assert := assert.New(t)
+	s := NewSyncSet[int]()
+	var wg sync.WaitGroup
+	added := make([]int, 8)
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if s.AddIfAbsent(i) {
+					added[w]++
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	total := 0
+	for _, n := range added {
+		total += n
+	}
+	assert.Equal(100, total)
+	assert.Equal(100, s.Len())
+
+	snapshot := s.Snapshot()
+	s.Clear()
+	assert.Equal(100, snapshot.Len())
+	assert.Equal(0, s.Len())
+}
+
+func TestSyncCounter(t *testing.T) {
+	assert := assert.New(t)
+	c := NewSyncCounter[string]()
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				c.Add("a")
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(8000, c.Count("a"))
+	assert.False(c.AddIfAbsent("a"))
+	assert.True(c.AddIfAbsent("b"))
+
+	snapshot := c.Snapshot()
+	assert.True(c.Equal(snapshot))
+	snapshot.Add("c")
+	assert.False(c.Contains("c"))
+	assert.Equal(8001, c.Len())
+}
+
+func TestSyncHeap(t *testing.T) {
+	assert := assert.New(t)
+	h := NewSyncHeap(Less[int])
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				h.Push(i)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(800, h.Len())
+	assert.Equal(0, h.Top())
+
+	_, ok := h.PopIf(func(value int) bool { return value > 0 })
+	assert.False(ok)
+	value, ok := h.PopIf(func(value int) bool { return value == 0 })
+	assert.True(ok)
+	assert.Equal(0, value)
+
+	values := slices.Collect(h.All())
+	assert.Len(values, 799)
+	assert.True(slices.IsSorted(values))
+	assert.Equal(799, h.Snapshot().Len())
+}
+
+func TestSyncListMethods(t *testing.T) {
+	assert := assert.New(t)
+	l := NewSyncList(1, 2, 3)
+	for i, value := range l.Iter() {
+		l.PushRight(value)
+		assert.Equal(i+1, value)
+	}
+	assert.Equal([]int{1, 2, 3, 1, 2, 3}, l.Elements())
+	var backward []int
+	for _, value := range l.Backward() {
+		backward = append(backward, value)
+	}
+	assert.Equal([]int{3, 2, 1, 3, 2, 1}, backward)
+	assert.Equal(6, l.Stream().Count())
+
+	tail, err := l.SplitAt(3)
+	assert.NoError(err)
+	assert.Equal([]int{1, 2, 3}, tail.Elements())
+	assert.NoError(l.Splice(0, NewList(0)))
+	l.Extend(tail)
+	assert.Equal([]int{0, 1, 2, 3, 1, 2, 3}, l.Elements())
+	assert.NoError(l.Validate())
+
+	value, err := l.PopErr()
+	assert.NoError(err)
+	assert.Equal(3, value)
+	empty := NewSyncList[int]()
+	_, err = empty.PopLeftErr()
+	assert.ErrorIs(err, ErrEmpty)
+	_, err = empty.PeekRightErr()
+	assert.ErrorIs(err, ErrEmpty)
+
+	data, err := json.Marshal(l)
+	assert.NoError(err)
+	var decoded SyncList[int]
+	assert.NoError(json.Unmarshal(data, &decoded))
+	assert.Equal(l.Elements(), decoded.Elements())
+}
+
+func TestSyncCollectionsMethods(t *testing.T) {
+	assert := assert.New(t)
+	c := NewSyncCounter("a", "a", "b")
+	counts := map[string]int{}
+	for element, count := range c.All() {
+		counts[element] = count
+	}
+	assert.Equal(map[string]int{"a": 2, "b": 1}, counts)
+	assert.Equal(3, c.Stream().Count())
+
+	data, err := NewSyncSet(1, 2).MarshalBinary()
+	assert.NoError(err)
+	var s SyncSet[int]
+	assert.NoError(s.UnmarshalBinary(data))
+	assert.ElementsMatch([]int{1, 2}, s.Elements())
+
+	h := NewSyncHeap(Less[int])
+	_, err = h.PopErr()
+	assert.ErrorIs(err, ErrEmpty)
+	h.Push(2, 1)
+	top, err := h.TopErr()
+	assert.NoError(err)
+	assert.Equal(1, top)
+	assert.NoError(h.Validate())
+}
@@ -0,0 +1,135 @@
+package q
+
+import "slices"
+
+// Tx is a transaction on a List, Set or Counter, started with Begin. While a transaction is open the collection
+// records an undo entry for each mutation, and Rollback replays them in reverse to restore the state at Begin.
+// Calling Begin again while a transaction is open starts a nested transaction that acts as a savepoint.
+// Transactions are not safe for concurrent use.
+type Tx struct {
+	log  *txLog
+	mark int
+	done bool
+}
+
+// txLog is the undo log shared by the open transactions of a collection. A collection without an open transaction
+// has no log, so recording costs a single nil check.
+type txLog struct {
+	undo      []func() error
+	open      []*Tx
+	replaying bool
+	release   func()
+	check     func()
+}
+
+// begin starts a transaction, or a savepoint if one is already open.
+// Time complexity: O(1).
+func (t *txLog) begin() *Tx {
+	tx := &Tx{log: t, mark: len(t.undo)}
+	t.open = append(t.open, tx)
+	return tx
+}
+
+// record adds an undo entry that always succeeds to the log.
+// Time complexity: amortized O(1).
+func (t *txLog) record(undo func()) {
+	t.recordChecked(func() error {
+		undo()
+		return nil
+	})
+}
+
+// recordChecked adds an undo entry to the log. The entry returns ErrTxConflict, without changing anything, if the
+// nodes it would restore have since been changed outside the transaction. Entries are not recorded while a rollback
+// is replaying the log.
+// Time complexity: amortized O(1).
+func (t *txLog) recordChecked(undo func() error) {
+	if !t.replaying {
+		t.undo = append(t.undo, undo)
+	}
+}
+
+// Commit keeps the changes made during the transaction. Committing a nested transaction hands its changes to the
+// enclosing transaction, and committing a transaction also finishes any transactions nested in it.
+// It returns ErrTxDone if the transaction has already finished.
+// Time complexity: O(1).
+func (tx *Tx) Commit() error {
+	return tx.finish(false)
+}
+
+// Rollback undoes the changes made during the transaction, including those of any transactions nested in it, and
+// finishes them. Node handles to removed list elements are relinked rather than recreated, so they remain valid.
+// Side effects such as eviction callbacks are not undone. It returns ErrTxDone if the transaction has already finished.
+// It returns ErrTxConflict if nodes recorded by the transaction have since been moved to or removed from another list;
+// the rollback then stops at that change and the transaction finishes with the remaining changes in place, leaving
+// every list involved valid.
+// Time complexity: O(k), where k is the number of changes made during the transaction.
+func (tx *Tx) Rollback() error {
+	return tx.finish(true)
+}
+
+// finish commits or rolls back the transaction and the transactions nested in it, and releases the log once the
+// outermost transaction has finished.
+// Time complexity: O(k), where k is the number of changes to roll back.
+func (tx *Tx) finish(rollback bool) error {
+	if tx.done {
+		return ErrTxDone
+	}
+	t := tx.log
+	i := slices.Index(t.open, tx)
+	for _, nested := range t.open[i:] {
+		nested.done = true
+	}
+	t.open = t.open[:i]
+	var err error
+	if rollback {
+		t.replaying = true
+		for j := len(t.undo) - 1; j >= tx.mark && err == nil; j-- {
+			err = t.undo[j]()
+		}
+		clear(t.undo[tx.mark:])
+		t.undo = t.undo[:tx.mark]
+		t.replaying = false
+		if t.check != nil {
+			t.check()
+		}
+	}
+	if len(t.open) == 0 {
+		t.undo = nil
+		t.release()
+	}
+	return err
+}
+
+// Begin starts a transaction on the list. Pushes, pops, insertions, removals, moves, sorting and the other
+// mutating methods are recorded until the transaction is committed or rolled back.
+// Time complexity: O(1).
+func (l *List[M]) Begin() *Tx {
+	if l.tx == nil {
+		l.tx = &txLog{
+			release: func() { l.tx = nil },
+			check:   l.check,
+		}
+	}
+	return l.tx.begin()
+}
+
+// Begin starts a transaction on the set. Add, Remove and Clear are recorded until the transaction is committed
+// or rolled back.
+// Time complexity: O(1).
+func (s *Set[T]) Begin() *Tx {
+	if s.tx == nil {
+		s.tx = &txLog{release: func() { s.tx = nil }}
+	}
+	return s.tx.begin()
+}
+
+// Begin starts a transaction on the counter. Add, Remove and Clear are recorded until the transaction is committed
+// or rolled back.
+// Time complexity: O(1).
+func (c *Counter[T]) Begin() *Tx {
+	if c.tx == nil {
+		c.tx = &txLog{release: func() { c.tx = nil }}
+	}
+	return c.tx.begin()
+}
@@ -0,0 +1,252 @@
+package q
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListTxRollback(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2, 3, 4, 5)
+	node := l.Front().Next()
+
+	tx := l.Begin()
+	l.PushRight(6)
+	l.PopLeft()
+	l.RemoveNode(node)
+	l.PushLeft(0)
+	assert.NoError(l.SetAt(1, 30))
+	l.Reverse()
+	l.Rotate(2)
+	l.SortInPlace(Less[int])
+	l.RemoveFunc(func(_ int, v int) bool { return v%2 == 0 })
+	tail, err := l.SplitAt(1)
+	assert.NoError(err)
+	tail.PushRight(7)
+	l.Clear()
+	l.PushRight(9)
+	assert.NoError(tx.Rollback())
+
+	assert.Equal([]int{1, 2, 3, 4, 5}, l.Elements())
+	assert.NoError(l.Validate())
+	assert.Equal(2, node.Value())
+	assert.Equal(3, node.Next().Value())
+	l.MoveToBack(node)
+	assert.Equal([]int{1, 3, 4, 5, 2}, l.Elements())
+	assert.Nil(l.tx)
+}
+
+func TestListTxRandom(t *testing.T) {
+	assert := assert.New(t)
+	for i := 0; i < 100; i++ {
+		l := NewList[int]()
+		for j := rand.Intn(20); j > 0; j-- {
+			l.PushRight(rand.Intn(10))
+		}
+		before := l.Elements()
+		tx := l.Begin()
+		for j := 0; j < 50; j++ {
+			switch rand.Intn(7) {
+			case 0:
+				l.PushRight(rand.Intn(10))
+			case 1:
+				l.PushLeft(rand.Intn(10))
+			case 2:
+				l.PopLeft()
+			case 3:
+				l.PopRight()
+			case 4:
+				_ = l.InsertAt(rand.Intn(l.Len()+1), rand.Intn(10))
+			case 5:
+				Remove(l, rand.Intn(10))
+			case 6:
+				l.Extend(NewList(rand.Intn(10), rand.Intn(10)))
+			}
+		}
+		assert.NoError(tx.Rollback())
+		assert.Equal(before, l.Elements())
+		assert.NoError(l.Validate())
+	}
+}
+
+func TestListTxCommit(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2)
+	tx := l.Begin()
+	l.PushRight(3)
+	assert.NoError(tx.Commit())
+	assert.Equal([]int{1, 2, 3}, l.Elements())
+	assert.ErrorIs(tx.Commit(), ErrTxDone)
+	assert.ErrorIs(tx.Rollback(), ErrTxDone)
+	assert.Nil(l.tx)
+}
+
+func TestListTxNested(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1)
+	outer := l.Begin()
+	l.PushRight(2)
+	inner := l.Begin()
+	l.PushRight(3)
+	assert.NoError(inner.Rollback())
+	assert.Equal([]int{1, 2}, l.Elements())
+
+	inner = l.Begin()
+	l.PushRight(4)
+	assert.NoError(inner.Commit())
+	assert.Equal([]int{1, 2, 4}, l.Elements())
+
+	inner = l.Begin()
+	l.PushRight(5)
+	assert.NoError(outer.Rollback())
+	assert.Equal([]int{1}, l.Elements())
+	assert.ErrorIs(inner.Rollback(), ErrTxDone)
+}
+
+func TestListTxMoveBetweenLists(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2)
+	other := NewList(3, 4)
+	tx := l.Begin()
+	l.Extend(other)
+	assert.Equal(0, other.Len())
+	assert.NoError(tx.Rollback())
+	assert.Equal([]int{1, 2}, l.Elements())
+	assert.Equal([]int{3, 4}, other.Elements())
+
+	tx = other.Begin()
+	assert.NoError(l.Splice(1, other))
+	assert.Equal([]int{1, 3, 4, 2}, l.Elements())
+	assert.NoError(tx.Rollback())
+	assert.Equal([]int{1, 2}, l.Elements())
+	assert.Equal([]int{3, 4}, other.Elements())
+	assert.NoError(l.Validate())
+	assert.NoError(other.Validate())
+}
+
+func TestListTxMovedNodes(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2)
+	other := NewList(3, 4, 5)
+	tx := l.Begin()
+	assert.NoError(l.Splice(1, other))
+	tail, err := l.SplitAt(3)
+	assert.NoError(err)
+	assert.Equal([]int{5, 2}, tail.Elements())
+	l.PushRight(6)
+	assert.NoError(tx.Rollback())
+	assert.Equal([]int{1, 2}, l.Elements())
+	assert.Equal([]int{3, 4, 5}, other.Elements())
+	assert.Equal([]int{}, tail.Elements())
+	assert.NoError(l.Validate())
+	assert.NoError(other.Validate())
+	assert.NoError(tail.Validate())
+}
+
+func TestListTxConflict(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2)
+	other := NewList(3, 4, 5)
+	tx := other.Begin()
+	assert.NoError(l.Splice(1, other))
+	_, err := l.RemoveAt(2)
+	assert.NoError(err)
+	assert.ErrorIs(tx.Rollback(), ErrTxConflict)
+	assert.ErrorIs(tx.Rollback(), ErrTxDone)
+	assert.Equal([]int{1, 3, 5, 2}, l.Elements())
+	assert.Equal([]int{}, other.Elements())
+	assert.NoError(l.Validate())
+	assert.NoError(other.Validate())
+
+	l = NewList(1, 2, 3)
+	tx = l.Begin()
+	tail, err := l.SplitAt(1)
+	assert.NoError(err)
+	tail.PopLeft()
+	tail.PushRight(7)
+	assert.ErrorIs(tx.Rollback(), ErrTxConflict)
+	assert.Equal([]int{1}, l.Elements())
+	assert.Equal([]int{3, 7}, tail.Elements())
+	assert.NoError(l.Validate())
+	assert.NoError(tail.Validate())
+
+	l = NewList(1, 2)
+	other = NewList[int]()
+	tx = l.Begin()
+	l.PushRight(3)
+	assert.NoError(other.Splice(0, l))
+	other.PopRight()
+	assert.ErrorIs(tx.Rollback(), ErrTxConflict)
+	assert.Equal(0, l.Len())
+	assert.Equal([]int{1, 2}, other.Elements())
+	assert.NoError(l.Validate())
+	assert.NoError(other.Validate())
+	l.PushRight(4)
+	assert.Equal([]int{4}, l.Elements())
+}
+
+func TestListTxBounded(t *testing.T) {
+	assert := assert.New(t)
+	l := NewBoundedList(3, 1, 2, 3)
+	tx := l.Begin()
+	l.PushRight(4, 5)
+	assert.Equal([]int{3, 4, 5}, l.Elements())
+	assert.NoError(tx.Rollback())
+	assert.Equal([]int{1, 2, 3}, l.Elements())
+}
+
+func TestSetTx(t *testing.T) {
+	assert := assert.New(t)
+	s := NewSet(1, 2)
+	tx := s.Begin()
+	s.Add(2, 3)
+	s.Remove(1)
+	s.Remove(5)
+	assert.ElementsMatch([]int{2, 3}, s.Elements())
+	assert.NoError(tx.Rollback())
+	assert.ElementsMatch([]int{1, 2}, s.Elements())
+
+	tx = s.Begin()
+	s.Clear()
+	s.Add(7)
+	assert.NoError(tx.Rollback())
+	assert.ElementsMatch([]int{1, 2}, s.Elements())
+	assert.Nil(s.tx)
+}
+
+func TestCounterTx(t *testing.T) {
+	assert := assert.New(t)
+	c := NewCounter("a", "a", "b")
+	tx := c.Begin()
+	c.Add("a", "c")
+	c.Remove("b", "d")
+	assert.Equal(3, c.Count("a"))
+	assert.Equal(0, c.Count("b"))
+	assert.NoError(tx.Rollback())
+	assert.True(c.Equal(NewCounter("a", "a", "b")))
+	assert.Equal(3, c.Len())
+
+	tx = c.Begin()
+	c.Clear()
+	c.Add("z")
+	assert.NoError(tx.Rollback())
+	assert.True(c.Equal(NewCounter("a", "a", "b")))
+	assert.Nil(c.tx)
+}
+
+// ExampleList_Begin demonstrates how to undo a batch of changes that fails validation.
+func ExampleList_Begin() {
+	l := NewList(1, 2, 3)
+	tx := l.Begin()
+	l.PushRight(4, -5, 6)
+	if l.Any(func(_ int, v int) bool { return v < 0 }) {
+		tx.Rollback()
+	} else {
+		tx.Commit()
+	}
+	fmt.Println(l)
+	// Output: [1 2 3]
+}
@@ -0,0 +1,115 @@
+package q
+
+import "fmt"
+
+// Validate checks the internal invariants of the list: the head and tail are the ends of the chain, every node's
+// prev and next links agree and point back to the list, the length matches the number of nodes and a bounded list
+// is within its maximum length. It returns an error wrapping ErrCorrupt describing the first violation found.
+// Time complexity: O(n), where n is the number of elements in the list.
+func (l *List[M]) Validate() error {
+	if err := l.validateEnds(); err != nil {
+		return err
+	}
+	count := int64(0)
+	var prev *Node[M]
+	for n := l.head; n != nil; n = n.next {
+		if count == l.length {
+			return fmt.Errorf("%w: list has more nodes than its length %d", ErrCorrupt, l.length)
+		}
+		if n.list != l {
+			return fmt.Errorf("%w: node at index %d belongs to another list", ErrCorrupt, count)
+		}
+		if n.prev != prev {
+			return fmt.Errorf("%w: node at index %d has a mismatched previous link", ErrCorrupt, count)
+		}
+		prev = n
+		count++
+	}
+	if prev != l.tail {
+		return fmt.Errorf("%w: list tail is not the last node", ErrCorrupt)
+	}
+	if count != l.length {
+		return fmt.Errorf("%w: list has %d nodes but length %d", ErrCorrupt, count, l.length)
+	}
+	return nil
+}
+
+// validateEnds checks the invariants that involve only the ends of the list: the head and tail are set together,
+// terminate the chain, link to their neighbours and belong to the list, and the length agrees with them and is
+// within the maximum length.
+// Time complexity: O(1).
+func (l *List[M]) validateEnds() error {
+	if (l.head == nil) != (l.tail == nil) {
+		return fmt.Errorf("%w: list head is %p but tail is %p", ErrCorrupt, l.head, l.tail)
+	}
+	if (l.head == nil) != (l.length == 0) || l.length < 0 {
+		return fmt.Errorf("%w: list length %d does not match its ends", ErrCorrupt, l.length)
+	}
+	if l.maxLen < 0 || (l.maxLen > 0 && l.length > int64(l.maxLen)) {
+		return fmt.Errorf("%w: list length %d exceeds maximum length %d", ErrCorrupt, l.length, l.maxLen)
+	}
+	if l.head == nil {
+		return nil
+	}
+	if (l.head == l.tail) != (l.length == 1) {
+		return fmt.Errorf("%w: list length %d does not match its ends", ErrCorrupt, l.length)
+	}
+	if l.head.prev != nil {
+		return fmt.Errorf("%w: list head has a previous node", ErrCorrupt)
+	}
+	if l.tail.next != nil {
+		return fmt.Errorf("%w: list tail has a next node", ErrCorrupt)
+	}
+	if l.head.list != l || l.tail.list != l {
+		return fmt.Errorf("%w: list end belongs to another list", ErrCorrupt)
+	}
+	if (l.head.next != nil && l.head.next.prev != l.head) || (l.tail.prev != nil && l.tail.prev.next != l.tail) {
+		return fmt.Errorf("%w: list end has a mismatched link", ErrCorrupt)
+	}
+	return nil
+}
+
+// Validate checks that every element of the heap satisfies the heap property with respect to its parent.
+// It returns an error wrapping ErrCorrupt describing the first violation found.
+// Time complexity: O(n), where n is the number of elements in the heap.
+func (h *Heap[M]) Validate() error {
+	for i := 1; i < len(h.data); i++ {
+		parent := (i - 1) / 2
+		if h.less(h.data[i], h.data[parent]) {
+			return fmt.Errorf("%w: heap element at index %d is less than its parent at index %d", ErrCorrupt, i, parent)
+		}
+	}
+	return nil
+}
+
+// check panics if the list is corrupt. With -tags qdebug_full it runs Validate; with -tags qdebug it only checks
+// the ends of the list.
+// Time complexity: O(1), or O(n) with -tags qdebug_full, where n is the number of elements in the list.
+func (l *List[M]) check() {
+	if debugFull {
+		if err := l.Validate(); err != nil {
+			panic(err)
+		}
+	} else if debug {
+		if err := l.validateEnds(); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// check panics if the heap is corrupt. With -tags qdebug_full it runs Validate; with -tags qdebug it only checks
+// the top and last elements, which every push and pop moves, against their parents.
+// Time complexity: O(1), or O(n) with -tags qdebug_full, where n is the number of elements in the heap.
+func (h *Heap[M]) check() {
+	if debugFull {
+		if err := h.Validate(); err != nil {
+			panic(err)
+		}
+	} else if debug {
+		for _, i := range []int{1, 2, len(h.data) - 1} {
+			if i > 0 && i < len(h.data) && h.less(h.data[i], h.data[(i-1)/2]) {
+				panic(fmt.Errorf("%w: heap element at index %d is less than its parent at index %d", ErrCorrupt, i, (i-1)/2))
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package q
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListValidate(t *testing.T) {
+	assert := assert.New(t)
+	l := NewList(1, 2, 3)
+	assert.NoError(l.Validate())
+	assert.NoError(NewList[int]().Validate())
+
+	l.PopLeft()
+	l.PopLeft()
+	l.PopLeft()
+	l.PopLeft()
+	assert.NoError(l.Validate())
+	assert.Equal(0, l.Len())
+
+	other := NewList(4, 5)
+	l.Extend(NewList[int](), other)
+	assert.NoError(l.Validate())
+	assert.NoError(other.Validate())
+
+	joined := Join(NewList(1), NewList(2))
+	assert.NoError(joined.Validate())
+}
+
+func TestListValidateCorrupt(t *testing.T) {
+	assert := assert.New(t)
+	corruptions := map[string]func(l *List[int]){
+		"length":      func(l *List[int]) { l.length++ },
+		"short":       func(l *List[int]) { l.length-- },
+		"tail":        func(l *List[int]) { l.tail = l.head },
+		"dangling":    func(l *List[int]) { l.head = nil },
+		"prev":        func(l *List[int]) { l.tail.prev = l.head },
+		"owner":       func(l *List[int]) { l.head.next.list = nil },
+		"head prev":   func(l *List[int]) { l.head.prev = l.tail },
+		"tail next":   func(l *List[int]) { l.tail.next = l.head },
+		"max length":  func(l *List[int]) { l.maxLen = 2 },
+		"cycle":       func(l *List[int]) { l.head.next.next = l.head.next },
+		"other owner": func(l *List[int]) { l.head.list = NewList[int]() },
+	}
+	for name, corrupt := range corruptions {
+		l := NewList(1, 2, 3)
+		corrupt(l)
+		assert.ErrorIs(l.Validate(), ErrCorrupt, name)
+	}
+}
+
+func TestHeapValidate(t *testing.T) {
+	assert := assert.New(t)
+	h := NewMinHeap(5, 3, 8, 1)
+	assert.NoError(h.Validate())
+	h.Pop()
+	assert.NoError(h.Validate())
+
+	h.data[0], h.data[len(h.data)-1] = h.data[len(h.data)-1], h.data[0]
+	assert.ErrorIs(h.Validate(), ErrCorrupt)
+}